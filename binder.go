@@ -0,0 +1,99 @@
+package httprequest
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sync"
+)
+
+type (
+	// Binder holds a configuration shared by every call made through it,
+	// along with its own cache of per-type binding plans. It is safe for
+	// concurrent use.
+	Binder struct {
+		cfg   config
+		plans sync.Map
+	}
+)
+
+var defaultBinder = New()
+
+func New(opts ...Option) *Binder {
+	b := &Binder{cfg: defaultCfg}
+	for _, opt := range opts {
+		opt(&b.cfg)
+	}
+	return b
+}
+
+// As binds req into obj like the package-level As, applying opts on top of
+// the options the Binder was created with.
+func (b *Binder) As(req *http.Request, obj any, opts ...Option) error {
+	cfg := b.cfg
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	v := reflect.ValueOf(obj)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("%w: %T", ErrInvalidTarget, obj)
+	}
+
+	state := binding{
+		binder:  b,
+		req:     req,
+		cfg:     &cfg,
+		headers: cfg.Header(req),
+	}
+	return state.bind(v.Elem())
+}
+
+// Sources returns the lookups of req for a generated binder, applying opts on
+// top of the options the Binder was created with.
+func (b *Binder) Sources(req *http.Request, opts ...Option) *Sources {
+	s := &Sources{cfg: b.cfg}
+	for _, opt := range opts {
+		opt(&s.cfg)
+	}
+
+	s.b = binding{
+		binder:  b,
+		req:     req,
+		cfg:     &s.cfg,
+		headers: s.cfg.Header(req),
+	}
+	return s
+}
+
+// Prepare builds and caches the binding plans of the types of objs, structs
+// or pointers to structs, returning the errors found in their tags, such as
+// invalid rules or defaults. Call it at startup so that bad tags fail there
+// rather than on the first request.
+func (b *Binder) Prepare(objs ...any) error {
+	var errs []error
+	for _, obj := range objs {
+		t := reflect.TypeOf(obj)
+		if t != nil && t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+		if t == nil || t.Kind() != reflect.Struct {
+			errs = append(errs, fmt.Errorf("%w: %T", ErrInvalidTarget, obj))
+			continue
+		}
+
+		if p := b.cachedPlan(t); len(p.errs) > 0 {
+			errs = append(errs, fmt.Errorf("%s: %w", t, p.errs))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// BindWith is Bind for a Binder; Go does not allow methods with type
+// parameters.
+func BindWith[T any](b *Binder, req *http.Request, opts ...Option) (T, error) {
+	var obj T
+	err := b.As(req, &obj, opts...)
+	return obj, err
+}
@@ -0,0 +1,148 @@
+package httprequest
+
+import (
+	"net/http"
+	"reflect"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBinder(t *testing.T) {
+	type paramStruct struct {
+		ID   int64  `from:"url-param=id"`
+		Sort string `from:"url-query=sort"`
+	}
+
+	binder := New(WithURLParamFunc(func(r *http.Request, key string) string {
+		return r.Header.Get("X-" + key)
+	}))
+
+	newRequest := func(t *testing.T, id string) *http.Request {
+		req, reqErr := http.NewRequest("GET", "/?sort=name", nil)
+		require.Nil(t, reqErr)
+
+		req.Header.Set("X-id", id)
+		return req
+	}
+
+	t.Run("should apply its options to every call", func(t *testing.T) {
+		obj := paramStruct{}
+		err := binder.As(newRequest(t, "7"), &obj)
+
+		require.Nil(t, err)
+		assert.Equal(t, paramStruct{ID: 7, Sort: "name"}, obj)
+
+		bound, err := BindWith[paramStruct](binder, newRequest(t, "8"))
+
+		require.Nil(t, err)
+		assert.Equal(t, paramStruct{ID: 8, Sort: "name"}, bound)
+	})
+
+	t.Run("should let call options override its options", func(t *testing.T) {
+		obj := paramStruct{}
+		err := binder.As(newRequest(t, "7"), &obj, WithURLParamFunc(func(r *http.Request, key string) string {
+			return "9"
+		}))
+
+		require.Nil(t, err)
+		assert.Equal(t, int64(9), obj.ID)
+
+		err = binder.As(newRequest(t, "7"), &obj)
+
+		require.Nil(t, err)
+		assert.Equal(t, int64(7), obj.ID)
+	})
+
+	t.Run("should let call converters override its converters", func(t *testing.T) {
+		type sortStruct struct {
+			Sort string `from:"url-query=sort"`
+		}
+
+		conv := func(suffix string) Option {
+			return WithConverter(reflect.TypeOf(""), func(s string, _ map[string]string) (any, error) {
+				return s + suffix, nil
+			})
+		}
+		b := New(conv("-binder"))
+
+		obj := sortStruct{}
+		err := b.As(newRequest(t, "7"), &obj, conv("-call"))
+
+		require.Nil(t, err)
+		assert.Equal(t, "name-call", obj.Sort)
+
+		err = b.As(newRequest(t, "7"), &obj)
+
+		require.Nil(t, err)
+		assert.Equal(t, "name-binder", obj.Sort)
+	})
+
+	t.Run("should keep its own plan cache", func(t *testing.T) {
+		type cacheStruct struct {
+			Sort string `from:"url-query=sort"`
+		}
+
+		typ := reflect.TypeOf(cacheStruct{})
+		other := New()
+
+		assert.NotSame(t, binder.cachedPlan(typ), other.cachedPlan(typ))
+		assert.Same(t, binder.cachedPlan(typ), binder.cachedPlan(typ))
+	})
+
+	t.Run("should prepare plans eagerly", func(t *testing.T) {
+		type goodStruct struct {
+			Page int `from:"url-query=page,default=1"`
+		}
+
+		type badStruct struct {
+			Page int `from:"url-query=page,default=first"`
+		}
+
+		other := New()
+
+		err := other.Prepare(goodStruct{}, &goodStruct{})
+
+		require.Nil(t, err)
+		_, cached := other.plans.Load(reflect.TypeOf(goodStruct{}))
+		assert.True(t, cached)
+
+		err = other.Prepare(goodStruct{}, &badStruct{})
+
+		var errs BindingErrors
+		require.ErrorAs(t, err, &errs)
+		require.Len(t, errs, 1)
+		assert.Equal(t, "Page", errs[0].Field)
+		assert.ErrorIs(t, err, ErrInvalidDefault)
+		assert.Contains(t, err.Error(), "badStruct")
+
+		err = other.Prepare(3, nil)
+
+		assert.ErrorIs(t, err, ErrInvalidTarget)
+	})
+
+	t.Run("should be safe for concurrent use", func(t *testing.T) {
+		reqs := make([]*http.Request, 16)
+		for i := range reqs {
+			reqs[i] = newRequest(t, strconv.Itoa(i))
+		}
+
+		var wg sync.WaitGroup
+		for i := range reqs {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+
+				obj := paramStruct{}
+				err := binder.As(reqs[i], &obj)
+
+				assert.Nil(t, err)
+				assert.Equal(t, int64(i), obj.ID)
+			}(i)
+		}
+		wg.Wait()
+	})
+}
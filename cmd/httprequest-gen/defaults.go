@@ -0,0 +1,101 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"go/types"
+	"net/http"
+	"reflect"
+	"strconv"
+	"time"
+
+	"github.com/jlucasnsilva/httprequest"
+)
+
+var basicTypes = map[types.BasicKind]reflect.Type{
+	types.Bool:    reflect.TypeOf(false),
+	types.Int:     reflect.TypeOf(int(0)),
+	types.Int8:    reflect.TypeOf(int8(0)),
+	types.Int16:   reflect.TypeOf(int16(0)),
+	types.Int32:   reflect.TypeOf(int32(0)),
+	types.Int64:   reflect.TypeOf(int64(0)),
+	types.Uint:    reflect.TypeOf(uint(0)),
+	types.Uint8:   reflect.TypeOf(uint8(0)),
+	types.Uint16:  reflect.TypeOf(uint16(0)),
+	types.Uint32:  reflect.TypeOf(uint32(0)),
+	types.Uint64:  reflect.TypeOf(uint64(0)),
+	types.Float32: reflect.TypeOf(float32(0)),
+	types.Float64: reflect.TypeOf(float64(0)),
+	types.String:  reflect.TypeOf(""),
+}
+
+// checkDefault mirrors the check httprequest makes on the default of a field
+// of type t when it builds a plan. The default is bound by httprequest itself,
+// to a struct holding a single field with the same tag. Fields of named types
+// other than time.Time are not checked: converters registered at run time may
+// accept their default.
+func (g *generator) checkDefault(t types.Type, tag httprequest.Tag, raw string) error {
+	if _, ok := tag.Default(); !ok {
+		return nil
+	}
+
+	switch {
+	case tag.Kind == fileTag || tag.Kind == requestBodyTag:
+		return fmt.Errorf("%w: not supported by %s", httprequest.ErrInvalidDefault, tag.Kind)
+	case types.Identical(t, g.cookieType) || types.Identical(t, types.NewPointer(g.cookieType)):
+		return fmt.Errorf("%w: not supported by %s", httprequest.ErrInvalidDefault, g.typeString(t))
+	}
+
+	rt, ok := g.reflectType(t)
+	if !ok {
+		return nil
+	}
+
+	obj := reflect.New(reflect.StructOf([]reflect.StructField{{
+		Name: "Field",
+		Type: rt,
+		Tag:  reflect.StructTag(tagName + ":" + strconv.Quote(raw)),
+	}}))
+
+	req, err := http.NewRequest("GET", "/", nil)
+	if err != nil {
+		return err
+	}
+
+	var errs httprequest.BindingErrors
+	if err := httprequest.As(req, obj.Interface()); errors.As(err, &errs) {
+		for _, e := range errs {
+			if errors.Is(e, httprequest.ErrInvalidDefault) {
+				return e.Err
+			}
+		}
+	}
+	return nil
+}
+
+// reflectType returns the reflect.Type of t when it is built from predeclared
+// types and time.Time alone.
+func (g *generator) reflectType(t types.Type) (reflect.Type, bool) {
+	if g.isTime(t) {
+		return reflect.TypeOf(time.Time{}), true
+	}
+
+	switch u := t.(type) {
+	case *types.Basic:
+		rt, ok := basicTypes[u.Kind()]
+		return rt, ok
+	case *types.Pointer:
+		if elem, ok := g.reflectType(u.Elem()); ok {
+			return reflect.PointerTo(elem), true
+		}
+	case *types.Slice:
+		if elem, ok := g.reflectType(u.Elem()); ok {
+			return reflect.SliceOf(elem), true
+		}
+	case *types.Array:
+		if elem, ok := g.reflectType(u.Elem()); ok {
+			return reflect.ArrayOf(int(u.Len()), elem), true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,943 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/format"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jlucasnsilva/httprequest"
+)
+
+const (
+	tagName       = "from"
+	generatedMark = "Code generated by httprequest-gen"
+	runtimePath   = "github.com/jlucasnsilva/httprequest"
+)
+
+const (
+	urlParamTag    = "url-param"
+	urlQueryTag    = "url-query"
+	requestBodyTag = "request-body"
+	headerTag      = "header"
+	cookieTag      = "cookie"
+	formTag        = "form"
+	fileTag        = "file"
+	groupTag       = "group"
+)
+
+type (
+	generator struct {
+		pkg      *types.Package
+		imports  map[string]string
+		metas    map[string]string
+		patterns map[string]string
+		chains   map[string]string
+		decls    []string
+		binder   string
+		buf      bytes.Buffer
+		depth    int
+
+		timeType        types.Type
+		cookieType      types.Type
+		fileHeaderType  types.Type
+		fileHeadersType types.Type
+		readCloserType  types.Type
+		bytesType       types.Type
+		textUnmarshaler *types.Interface
+	}
+
+	bindField struct {
+		name   string
+		expr   string
+		typ    types.Type
+		tag    httprequest.Tag
+		rules  httprequest.Rules
+		allocs []alloc
+	}
+
+	// alloc is an embedded or group struct pointer that must be allocated
+	// before a field can be set through it.
+	alloc struct {
+		expr string
+		typ  types.Type
+	}
+
+	collector struct {
+		g       *generator
+		root    *types.Named
+		fields  []bindField
+		errs    []error
+		hasBody bool
+		groups  map[types.Type]bool
+	}
+)
+
+func newGenerator(dir string) (*generator, error) {
+	bp, err := build.ImportDir(dir, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		files []*ast.File
+
+		fset = token.NewFileSet()
+	)
+
+	for _, name := range bp.GoFiles {
+		f, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, parser.ParseComments)
+		if err != nil {
+			return nil, err
+		}
+		if isGenerated(f) {
+			continue
+		}
+		files = append(files, f)
+	}
+
+	path, exports, err := exportData(dir, "encoding", "io", "mime/multipart", "net/http", "time")
+	if err != nil {
+		return nil, err
+	}
+
+	imp := importer.ForCompiler(fset, "gc", func(path string) (io.ReadCloser, error) {
+		file := exports[path]
+		if file == "" {
+			return nil, fmt.Errorf("no export data for %s", path)
+		}
+		return os.Open(file)
+	})
+
+	// The package may not type check before its binders are generated, so
+	// errors are ignored as long as the requested types resolve.
+	conf := types.Config{
+		Importer: imp,
+		Error:    func(error) {},
+	}
+	pkg, _ := conf.Check(path, fset, files, nil)
+
+	g := generator{pkg: pkg}
+	lookup := func(path, name string) (types.Type, error) {
+		p, err := imp.Import(path)
+		if err != nil {
+			return nil, err
+		}
+		return p.Scope().Lookup(name).Type(), nil
+	}
+
+	if g.timeType, err = lookup("time", "Time"); err != nil {
+		return nil, err
+	}
+	if g.cookieType, err = lookup("net/http", "Cookie"); err != nil {
+		return nil, err
+	}
+	if g.fileHeaderType, err = lookup("mime/multipart", "FileHeader"); err != nil {
+		return nil, err
+	}
+	if g.readCloserType, err = lookup("io", "ReadCloser"); err != nil {
+		return nil, err
+	}
+
+	tu, err := lookup("encoding", "TextUnmarshaler")
+	if err != nil {
+		return nil, err
+	}
+
+	g.fileHeaderType = types.NewPointer(g.fileHeaderType)
+	g.fileHeadersType = types.NewSlice(g.fileHeaderType)
+	g.bytesType = types.NewSlice(types.Typ[types.Byte])
+	g.textUnmarshaler = tu.Underlying().(*types.Interface)
+	return &g, nil
+}
+
+// exportData lists the compiled export data of the package in dir, its
+// dependencies and the extra packages the generator inspects.
+func exportData(dir string, extra ...string) (string, map[string]string, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return "", nil, err
+	}
+
+	args := append([]string{"list", "-e", "-export", "-deps", "-f", "{{.ImportPath}}\t{{.Export}}\t{{.Dir}}", "."}, extra...)
+	cmd := exec.Command("go", args...)
+	cmd.Dir = dir
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		return "", nil, fmt.Errorf("go list: %w\n%s", err, stderr.Bytes())
+	}
+
+	var (
+		path    string
+		exports = make(map[string]string)
+	)
+
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		parts := strings.Split(line, "\t")
+		if len(parts) != 3 {
+			continue
+		}
+
+		exports[parts[0]] = parts[1]
+		if parts[2] == abs {
+			path = parts[0]
+		}
+	}
+	return path, exports, nil
+}
+
+func isGenerated(f *ast.File) bool {
+	for _, c := range f.Comments {
+		if c.Pos() > f.Package {
+			break
+		}
+		if strings.Contains(c.Text(), generatedMark) {
+			return true
+		}
+	}
+	return false
+}
+
+func (g *generator) generate(names []string) ([]byte, error) {
+	g.reset()
+	g.imports[runtimePath] = "httprequest"
+	g.imports["net/http"] = "http"
+
+	for _, name := range names {
+		named, err := g.lookupStruct(name)
+		if err != nil {
+			return nil, err
+		}
+
+		fields, err := g.fields(named)
+		if err != nil {
+			return nil, err
+		}
+		g.emitBinder(name, fields)
+	}
+	return g.source()
+}
+
+func (g *generator) reset() {
+	g.buf.Reset()
+	g.imports = make(map[string]string)
+	g.metas = make(map[string]string)
+	g.patterns = make(map[string]string)
+	g.chains = make(map[string]string)
+	g.decls = nil
+	g.depth = 0
+}
+
+func (g *generator) source() ([]byte, error) {
+	var (
+		out   bytes.Buffer
+		paths []string
+	)
+
+	for path := range g.imports {
+		paths = append(paths, path)
+	}
+	sort.Slice(paths, func(i, j int) bool {
+		if isStd(paths[i]) != isStd(paths[j]) {
+			return isStd(paths[i])
+		}
+		return paths[i] < paths[j]
+	})
+
+	fmt.Fprintf(&out, "// %s; DO NOT EDIT.\n\n", generatedMark)
+	fmt.Fprintf(&out, "package %s\n\n", g.pkg.Name())
+	fmt.Fprintf(&out, "import (\n")
+	for i, path := range paths {
+		if i > 0 && isStd(paths[i-1]) && !isStd(path) {
+			fmt.Fprintf(&out, "\n")
+		}
+		fmt.Fprintf(&out, "\t%q\n", path)
+	}
+	fmt.Fprintf(&out, ")\n")
+	out.Write(g.buf.Bytes())
+	if len(g.decls) > 0 {
+		fmt.Fprintf(&out, "\nvar (\n%s)\n", strings.Join(g.decls, ""))
+	}
+
+	src, err := format.Source(out.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("invalid generated code: %w\n%s", err, out.Bytes())
+	}
+	return src, nil
+}
+
+func isStd(path string) bool {
+	first, _, _ := strings.Cut(path, "/")
+	return !strings.Contains(first, ".")
+}
+
+func (g *generator) lookupStruct(name string) (*types.Named, error) {
+	obj := g.pkg.Scope().Lookup(name)
+	if obj == nil {
+		return nil, fmt.Errorf("type %s not found in package %s", name, g.pkg.Name())
+	}
+
+	named, ok := obj.Type().(*types.Named)
+	if !ok {
+		return nil, fmt.Errorf("%s is not a named type", name)
+	}
+	if _, ok := named.Underlying().(*types.Struct); !ok {
+		return nil, fmt.Errorf("%s is not a struct type", name)
+	}
+	return named, nil
+}
+
+// fields returns the bindable fields of named in the order httprequest.As
+// binds them, descending into embedded structs and groups.
+func (g *generator) fields(named *types.Named) ([]bindField, error) {
+	c := collector{
+		g:      g,
+		root:   named,
+		groups: map[types.Type]bool{named: true},
+	}
+	c.collect(named, "obj", nil, "")
+	return c.fields, errors.Join(c.errs...)
+}
+
+// collect mirrors reflect.VisibleFields: a depth-first walk over embedded
+// structs that drops the fields hidden by shallower ones.
+func (c *collector) collect(typ types.Type, expr string, allocs []alloc, prefix string) {
+	type candidate struct {
+		v      *types.Var
+		tag    string
+		index  []int
+		expr   string
+		allocs []alloc
+	}
+
+	var (
+		cands  []candidate
+		groups [][]int
+		visit  func(st *types.Struct, index []int, expr string, allocs []alloc)
+
+		visited = map[types.Type]bool{typ: true}
+	)
+
+	visit = func(st *types.Struct, index []int, expr string, allocs []alloc) {
+		for i := 0; i < st.NumFields(); i++ {
+			v := st.Field(i)
+			c := candidate{
+				v:      v,
+				tag:    st.Tag(i),
+				index:  append(append([]int(nil), index...), i),
+				expr:   expr + "." + v.Name(),
+				allocs: allocs,
+			}
+			cands = append(cands, c)
+
+			if !v.Embedded() {
+				continue
+			}
+
+			typ := v.Type()
+			inner := allocs
+			if ptr, ok := typ.(*types.Pointer); ok {
+				typ = ptr.Elem()
+				inner = append(append([]alloc(nil), allocs...), alloc{expr: c.expr, typ: typ})
+			}
+			if st, ok := typ.Underlying().(*types.Struct); ok && !visited[typ] {
+				visited[typ] = true
+				visit(st, c.index, c.expr, inner)
+			}
+		}
+	}
+
+	visit(typ.Underlying().(*types.Struct), nil, expr, allocs)
+
+	for _, cand := range cands {
+		obj, index, _ := types.LookupFieldOrMethod(typ, true, c.g.pkg, cand.v.Name())
+		if obj != cand.v || !equalIndex(index, cand.index) || isPromotedFrom(cand.index, groups) {
+			continue
+		}
+
+		tag := reflect.StructTag(cand.tag).Get(tagName)
+		if tag == "" || tag == "-" {
+			continue
+		}
+
+		name := strings.TrimPrefix(cand.expr, "obj.")
+		fail := func(err error) {
+			c.errs = append(c.errs, fmt.Errorf("%s.%s: %w", c.root.Obj().Name(), name, err))
+		}
+
+		t, err := httprequest.ParseTag(tag)
+		if err != nil {
+			fail(err)
+			continue
+		}
+		if t.Kind != requestBodyTag && t.Kind != groupTag {
+			t.Source = prefix + t.Source
+		}
+		for i := range t.Fallbacks {
+			if k := t.Fallbacks[i].Kind; k != requestBodyTag && k != groupTag {
+				t.Fallbacks[i].Source = prefix + t.Fallbacks[i].Source
+			}
+		}
+		if t.Fallbacks != nil {
+			if err := c.g.checkChain(cand.v.Type(), t); err != nil {
+				fail(err)
+				continue
+			}
+		}
+
+		if !cand.v.Exported() || !exportedAllocs(cand.allocs) {
+			fail(httprequest.ErrUnexportedField)
+			continue
+		}
+
+		switch t.Kind {
+		case urlParamTag, urlQueryTag, headerTag, cookieTag, formTag:
+		case fileTag:
+			if !c.g.isFileType(cand.v.Type()) {
+				fail(httprequest.ErrInvalidFileField)
+				continue
+			}
+		case requestBodyTag:
+			if c.hasBody {
+				fail(httprequest.ErrDuplicateBody)
+				continue
+			}
+			c.hasBody = true
+			if _, err := t.MaxBytes(); err != nil {
+				fail(err)
+				continue
+			}
+		case groupTag:
+			groups = append(groups, cand.index)
+
+			typ := cand.v.Type()
+			inner := cand.allocs
+			if ptr, ok := typ.(*types.Pointer); ok {
+				typ = ptr.Elem()
+				inner = append(append([]alloc(nil), inner...), alloc{expr: cand.expr, typ: typ})
+			}
+			if _, ok := typ.Underlying().(*types.Struct); !ok {
+				fail(httprequest.ErrInvalidGroup)
+			} else if c.groups[typ] {
+				fail(httprequest.ErrRecursiveGroup)
+			} else {
+				c.groups[typ] = true
+				c.collect(typ, cand.expr, inner, prefix+t.Meta["prefix"])
+				delete(c.groups, typ)
+			}
+			continue
+		default:
+			fail(fmt.Errorf("%w: %s", httprequest.ErrUnknownSourceKind, t.Kind))
+			continue
+		}
+
+		rules, err := t.Rules()
+		if err == nil {
+			err = c.g.checkRules(t.Kind, cand.v.Type(), rules)
+		}
+		if err == nil {
+			err = c.g.checkDefault(cand.v.Type(), t, tag)
+		}
+		if err != nil {
+			fail(err)
+			continue
+		}
+
+		c.fields = append(c.fields, bindField{
+			name:   name,
+			expr:   cand.expr,
+			typ:    cand.v.Type(),
+			tag:    t,
+			rules:  rules,
+			allocs: cand.allocs,
+		})
+	}
+}
+
+// isPromotedFrom reports whether the field at index was promoted from one of
+// the embedded groups, which are collected on their own.
+func isPromotedFrom(index []int, groups [][]int) bool {
+	for _, g := range groups {
+		if len(index) > len(g) && equalIndex(index[:len(g)], g) {
+			return true
+		}
+	}
+	return false
+}
+
+// exportedAllocs mirrors httprequest.As, which cannot allocate embedded
+// pointers to unexported types.
+func exportedAllocs(allocs []alloc) bool {
+	for _, a := range allocs {
+		if !token.IsExported(a.expr[strings.LastIndex(a.expr, ".")+1:]) {
+			return false
+		}
+	}
+	return true
+}
+
+func equalIndex(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func (g *generator) printf(format string, args ...any) {
+	fmt.Fprintf(&g.buf, format, args...)
+}
+
+func (g *generator) qualifier(p *types.Package) string {
+	if p == g.pkg {
+		return ""
+	}
+	g.imports[p.Path()] = p.Name()
+	return p.Name()
+}
+
+func (g *generator) typeString(t types.Type) string {
+	return types.TypeString(t, g.qualifier)
+}
+
+func (g *generator) use(path string) {
+	g.imports[path] = path[strings.LastIndex(path, "/")+1:]
+}
+
+func (g *generator) tmp(prefix string) string {
+	g.depth++
+	return prefix + strconv.Itoa(g.depth)
+}
+
+func (g *generator) emitBinder(name string, fields []bindField) {
+	g.binder = "bind" + name
+	g.use("fmt")
+	g.printf("\nfunc Bind%s(req *http.Request, obj *%s, opts ...httprequest.Option) error {\n", name, name)
+	g.printf("return %s(httprequest.NewSources(req, opts...), obj)\n}\n", g.binder)
+	g.printf("\n// Bind%sWith is Bind%s with the options and plans of b.\n", name, name)
+	g.printf("func Bind%sWith(b *httprequest.Binder, req *http.Request, obj *%s, opts ...httprequest.Option) error {\n", name, name)
+	g.printf("return %s(b.Sources(req, opts...), obj)\n}\n", g.binder)
+
+	g.printf("\nfunc %s(s *httprequest.Sources, obj *%s) error {\n", g.binder, name)
+	g.printf("if obj == nil {\nreturn fmt.Errorf(\"%%w: %%T\", httprequest.ErrInvalidTarget, obj)\n}\n\n")
+	if len(fields) < 1 {
+		g.printf("if errs := s.ValidateTarget(nil, obj); len(errs) > 0 {\nreturn errs\n}\n")
+		g.printf("return nil\n}\n")
+		return
+	}
+
+	g.printf("var errs httprequest.BindingErrors\n\n")
+	g.printf("fail := func(field, kind, source, value string, err error, tried ...httprequest.TagSource) {\n")
+	g.printf("errs = append(errs, &httprequest.FieldError{Field: field, Kind: kind, Source: source, Value: value, Err: err, Tried: tried})\n")
+	g.printf("}\n")
+
+	var body *bindField
+	for i := range fields {
+		if fields[i].tag.Kind == requestBodyTag {
+			body = &fields[i]
+		}
+	}
+	if body != nil {
+		g.printf("\n// body is set once the request body is bound.\nvar body any\n")
+	}
+
+	for _, f := range fields {
+		g.depth = 0
+		g.printf("\n// %s\n", f.name)
+		g.emitField(f)
+		g.printf("if len(errs) > 0 && s.FailFast() {\nreturn errs\n}\n")
+	}
+
+	g.printf("\n")
+	if body != nil {
+		g.printf("if body != nil {\nerrs = s.ValidateBody(errs, %q, body)\n}\n", body.name)
+	}
+	g.printf("if errs = s.ValidateTarget(errs, obj); len(errs) > 0 {\nreturn errs\n}\nreturn nil\n}\n")
+}
+
+func (g *generator) emitField(f bindField) {
+	fail := func(value string) string {
+		return fmt.Sprintf("fail(%q, %q, %q, %s, err)", f.name, f.tag.Kind, f.tag.Source, value)
+	}
+
+	_, pointer := f.typ.(*types.Pointer)
+	if f.tag.Fallbacks != nil {
+		g.emitChain(f, pointer)
+		return
+	}
+
+	switch f.tag.Kind {
+	case cookieTag:
+		g.use("errors")
+		if def, ok := f.tag.Default(); ok {
+			g.printf("if c, err := s.Cookie(%q); err != nil && !errors.Is(err, http.ErrNoCookie) {\n%s\n", f.tag.Source, fail(`""`))
+			g.printf("} else if err := func() error {\n")
+			g.printf("if err != nil {\nc = &http.Cookie{Name: %q, Value: %q}\n}\n", f.tag.Source, def)
+			g.emitAllocs(f)
+			g.printf("params := []string{c.Value}\n")
+			g.emitValues(f.expr, f.typ, "params", f.tag, true)
+			g.emitRules(f.expr, f.typ, f.rules)
+			g.printf("return nil\n}(); err != nil {\n%s\n}\n", fail("c.Value"))
+			return
+		}
+
+		g.printf("if c, err := s.Cookie(%q); err != nil {\n", f.tag.Source)
+		if pointer && f.rules.Required {
+			g.printf("if errors.Is(err, http.ErrNoCookie) {\nerr = httprequest.ErrRequired\n}\n%s\n", fail(`""`))
+		} else if pointer {
+			g.printf("if !errors.Is(err, http.ErrNoCookie) {\n%s\n}\n", fail(`""`))
+		} else {
+			g.printf("%s\n", fail(`""`))
+		}
+		g.printf("} else if err := func() error {\n")
+		g.emitAllocs(f)
+		switch {
+		case types.Identical(f.typ, g.cookieType):
+			g.printf("%s = *c\n", f.expr)
+		case types.Identical(f.typ, types.NewPointer(g.cookieType)):
+			g.printf("%s = c\n", f.expr)
+		default:
+			g.printf("params := []string{c.Value}\n")
+			g.emitValues(f.expr, f.typ, "params", f.tag, true)
+			g.emitRules(f.expr, f.typ, f.rules)
+		}
+		g.printf("return nil\n}(); err != nil {\n%s\n}\n", fail("c.Value"))
+	case fileTag:
+		g.printf("if headers, err := s.Files(%q); err != nil {\n%s\n", f.tag.Source, fail(`""`))
+		g.printf("} else if err := func() error {\n")
+		g.emitAllocs(f)
+		g.emitFile(f.expr, f.typ)
+		g.printf("return nil\n}(); err != nil {\n%s\n}\n", fail(`""`))
+	case requestBodyTag:
+		g.emitAllocs(f)
+		target := "&" + f.expr
+		if ptr, ok := f.typ.(*types.Pointer); ok {
+			g.printf("if %s == nil {\n%s = new(%s)\n}\n", f.expr, f.expr, g.typeString(ptr.Elem()))
+			target = f.expr
+		}
+		if n, _ := f.tag.MaxBytes(); n > 0 {
+			g.printf("if err := s.DecodeMax(%s, %d); err != nil {\n%s\n", target, n, fail(`""`))
+		} else {
+			g.printf("if err := s.Decode(%s); err != nil {\n%s\n", target, fail(`""`))
+		}
+		g.printf("} else {\nbody = %s\n}\n", target)
+	default:
+		g.use("strings")
+		g.printf("if params, err := s.Values(%q, %q); err != nil {\n%s\n", f.tag.Kind, f.tag.Source, fail(`""`))
+		if def, ok := f.tag.Default(); ok {
+			g.printf("} else {\n")
+			if pointer {
+				g.printf("if len(params) < 1 {\n")
+			} else {
+				g.printf("if len(params) < 1 || (len(params) == 1 && params[0] == \"\") {\n")
+			}
+			g.printf("params = []string{%q}\n}\n", def)
+			g.printf("if err := func() error {\n")
+			g.emitAllocs(f)
+			g.emitValues(f.expr, f.typ, "params", f.tag, true)
+			g.emitRules(f.expr, f.typ, f.rules)
+			g.printf("return nil\n}(); err != nil {\n%s\n}\n}\n", fail(`strings.Join(params, ",")`))
+			return
+		}
+		if pointer {
+			g.printf("} else if len(params) > 0 {\n")
+		} else {
+			g.printf("} else if len(params) > 1 || (len(params) == 1 && params[0] != \"\") {\n")
+		}
+		g.printf("if err := func() error {\n")
+		g.emitAllocs(f)
+		g.emitValues(f.expr, f.typ, "params", f.tag, true)
+		g.emitRules(f.expr, f.typ, f.rules)
+		g.printf("return nil\n}(); err != nil {\n%s\n}\n", fail(`strings.Join(params, ",")`))
+		if f.rules.Required {
+			g.printf("} else {\nfail(%q, %q, %q, \"\", httprequest.ErrRequired)\n", f.name, f.tag.Kind, f.tag.Source)
+		}
+		g.printf("}\n")
+	}
+}
+
+// emitChain mirrors bindChain: f is bound from the first of its sources
+// that has a value.
+func (g *generator) emitChain(f bindField, pointer bool) {
+	fail := func(value, err string) string {
+		return fmt.Sprintf("fail(%q, src.Kind, src.Source, %s, %s, tried...)", f.name, value, err)
+	}
+
+	g.use("strings")
+	g.printf("if src, params, tried, err := s.First(%s, %t); err != nil {\n%s\n", g.chain(f.tag), pointer, fail(`""`, "err"))
+	if def, ok := f.tag.Default(); ok {
+		g.printf("} else {\nif len(params) < 1 {\nparams = []string{%q}\n}\n", def)
+	} else {
+		g.printf("} else if len(params) > 0 {\n")
+	}
+	g.printf("if err := func() error {\n")
+	g.emitAllocs(f)
+	g.emitValues(f.expr, f.typ, "params", f.tag, true)
+	g.emitRules(f.expr, f.typ, f.rules)
+	g.printf("return nil\n}(); err != nil {\n%s\n}\n", fail(`strings.Join(params, ",")`, "err"))
+	if _, ok := f.tag.Default(); !ok && f.rules.Required {
+		g.printf("} else {\n%s\n", fail(`""`, "httprequest.ErrRequired"))
+	}
+	g.printf("}\n")
+}
+
+// chain returns the package-level variable holding the sources of tag.
+func (g *generator) chain(tag httprequest.Tag) string {
+	sources := append([]httprequest.TagSource{{Kind: tag.Kind, Source: tag.Source}}, tag.Fallbacks...)
+
+	elems := make([]string, len(sources))
+	for i, s := range sources {
+		elems[i] = fmt.Sprintf("{Kind: %q, Source: %q}", s.Kind, s.Source)
+	}
+
+	lit := "[]httprequest.TagSource{" + strings.Join(elems, ", ") + "}"
+	key := g.binder + lit
+	if name, ok := g.chains[key]; ok {
+		return name
+	}
+
+	name := g.binder + "Chain" + strconv.Itoa(len(g.chains)+1)
+	g.chains[key] = name
+	g.decls = append(g.decls, fmt.Sprintf("%s = %s\n", name, lit))
+	return name
+}
+
+// checkChain mirrors the check httprequest makes on fields with fallbacks.
+func (g *generator) checkChain(t types.Type, tag httprequest.Tag) error {
+	if types.Identical(t, g.cookieType) || types.Identical(t, types.NewPointer(g.cookieType)) {
+		return fmt.Errorf("%w: not supported by %s", httprequest.ErrInvalidFallback, g.typeString(t))
+	}
+
+	kinds := []string{tag.Kind}
+	for _, s := range tag.Fallbacks {
+		kinds = append(kinds, s.Kind)
+	}
+	for _, k := range kinds {
+		switch k {
+		case urlParamTag, urlQueryTag, headerTag, cookieTag, formTag:
+		default:
+			return fmt.Errorf("%w: %s", httprequest.ErrInvalidFallback, k)
+		}
+	}
+	return nil
+}
+
+func (g *generator) emitAllocs(f bindField) {
+	for _, a := range f.allocs {
+		g.printf("if %s == nil {\n%s = new(%s)\n}\n", a.expr, a.expr, g.typeString(a.typ))
+	}
+}
+
+func (g *generator) emitFile(dst string, t types.Type) {
+	switch {
+	case types.Identical(t, g.fileHeaderType):
+		g.printf("%s = headers[0]\n", dst)
+	case types.Identical(t, g.fileHeadersType):
+		g.printf("%s = headers\n", dst)
+	case types.Identical(t, g.readCloserType):
+		g.printf("file, err := headers[0].Open()\nif err != nil {\nreturn err\n}\n")
+		g.printf("%s = file\n", dst)
+	case types.Identical(t, g.bytesType):
+		g.use("io")
+		g.printf("file, err := headers[0].Open()\nif err != nil {\nreturn err\n}\ndefer file.Close()\n\n")
+		g.printf("data, err := io.ReadAll(file)\nif err != nil {\nreturn err\n}\n")
+		g.printf("%s = data\n", dst)
+	}
+}
+
+func (g *generator) isFileType(t types.Type) bool {
+	return types.Identical(t, g.fileHeaderType) ||
+		types.Identical(t, g.fileHeadersType) ||
+		types.Identical(t, g.readCloserType) ||
+		types.Identical(t, g.bytesType)
+}
+
+func (g *generator) isTime(t types.Type) bool {
+	return types.Identical(t, g.timeType)
+}
+
+func (g *generator) isTextUnmarshaler(t types.Type) bool {
+	return !g.isTime(t) && types.Implements(types.NewPointer(t), g.textUnmarshaler)
+}
+
+// emitValues mirrors setValues: dst receives every value in params.
+func (g *generator) emitValues(dst string, t types.Type, params string, tag httprequest.Tag, top bool) {
+	if sep := tag.Separator(); sep != "" && top {
+		split := g.tmp("split")
+		g.printf("%s := make([]string, 0, len(%s))\n", split, params)
+		g.printf("for _, p := range %s {\n%s = append(%s, strings.Split(p, %q)...)\n}\n", params, split, split, sep)
+		params = split
+	}
+
+	g.printf("if ok, err := s.Convert(%s, %s[0], %s); ok {\nif err != nil {\nreturn err\n}\n} else {\n", addr(dst), params, g.meta(tag))
+	defer g.printf("}\n")
+
+	if g.isTextUnmarshaler(t) {
+		g.emitValue(dst, t, params+"[0]", tag)
+		return
+	}
+
+	switch u := t.Underlying().(type) {
+	case *types.Pointer:
+		p := g.tmp("p")
+		g.printf("%s := new(%s)\n", p, g.typeString(u.Elem()))
+		g.emitValues("*"+p, u.Elem(), params, tag, false)
+		g.printf("%s = %s\n", dst, p)
+	case *types.Slice:
+		sl := g.tmp("s")
+		g.printf("%s := make(%s, len(%s))\n", sl, g.typeString(t), params)
+		g.emitElements(sl, u.Elem(), params, tag)
+		g.printf("%s = %s\n", dst, sl)
+	case *types.Array:
+		g.use("fmt")
+		a := g.tmp("a")
+		g.printf("if len(%s) != %d {\n", params, u.Len())
+		g.printf("return fmt.Errorf(\"%%w: got %%d values, want %%d\", httprequest.ErrArrayLength, len(%s), %d)\n}\n", params, u.Len())
+		g.printf("var %s %s\n", a, g.typeString(t))
+		g.emitElements(a, u.Elem(), params, tag)
+		g.printf("%s = %s\n", dst, a)
+	default:
+		g.emitValue(dst, t, params+"[0]", tag)
+	}
+}
+
+func (g *generator) emitElements(dst string, elem types.Type, params string, tag httprequest.Tag) {
+	i := g.tmp("i")
+	g.printf("for %s := range %s {\n", i, params)
+	g.printf("if err := func() error {\n")
+	g.printf("if ok, err := s.Convert(&%s[%s], %s[%s], %s); ok {\nreturn err\n}\n", dst, i, params, i, g.meta(tag))
+	g.emitValue(dst+"["+i+"]", elem, params+"["+i+"]", tag)
+	g.printf("return nil\n}(); err != nil {\n")
+	g.printf("return fmt.Errorf(\"element %%d: %%w\", %s, err)\n}\n}\n", i)
+}
+
+// emitValue mirrors setValue: dst receives the single value raw.
+func (g *generator) emitValue(dst string, t types.Type, raw string, tag httprequest.Tag) {
+	if g.isTextUnmarshaler(t) {
+		g.printf("if err := %s.UnmarshalText([]byte(%s)); err != nil {\nreturn err\n}\n", recv(dst), raw)
+		return
+	}
+
+	if g.isTime(t) {
+		g.use("time")
+		g.printf("if v, err := time.Parse(%q, %s); err != nil {\nreturn err\n} else {\n%s = v\n}\n", tag.TimeLayout(), raw, dst)
+		return
+	}
+
+	basic, ok := t.Underlying().(*types.Basic)
+	if !ok {
+		return
+	}
+
+	var (
+		parse  string
+		result types.BasicKind
+	)
+
+	switch basic.Kind() {
+	case types.Bool:
+		parse, result = fmt.Sprintf("strconv.ParseBool(%s)", raw), types.Bool
+	case types.Int, types.Int64:
+		parse, result = fmt.Sprintf("strconv.ParseInt(%s, 10, 64)", raw), types.Int64
+	case types.Int8:
+		parse, result = fmt.Sprintf("strconv.ParseInt(%s, 10, 8)", raw), types.Int64
+	case types.Int16:
+		parse, result = fmt.Sprintf("strconv.ParseInt(%s, 10, 16)", raw), types.Int64
+	case types.Int32:
+		parse, result = fmt.Sprintf("strconv.ParseInt(%s, 10, 32)", raw), types.Int64
+	case types.Uint, types.Uint64:
+		parse, result = fmt.Sprintf("strconv.ParseUint(%s, 10, 64)", raw), types.Uint64
+	case types.Uint8:
+		parse, result = fmt.Sprintf("strconv.ParseUint(%s, 10, 8)", raw), types.Uint64
+	case types.Uint16:
+		parse, result = fmt.Sprintf("strconv.ParseUint(%s, 10, 16)", raw), types.Uint64
+	case types.Uint32:
+		parse, result = fmt.Sprintf("strconv.ParseUint(%s, 10, 32)", raw), types.Uint64
+	case types.Float32:
+		parse, result = fmt.Sprintf("strconv.ParseFloat(%s, 32)", raw), types.Float64
+	case types.Float64:
+		parse, result = fmt.Sprintf("strconv.ParseFloat(%s, 64)", raw), types.Float64
+	case types.String:
+		g.printf("%s = %s\n", dst, g.convert(t, types.String, raw))
+		return
+	default:
+		return
+	}
+
+	g.use("strconv")
+	g.printf("if v, err := %s; err != nil {\nreturn err\n} else {\n%s = %s\n}\n", parse, dst, g.convert(t, result, "v"))
+}
+
+// convert returns expr, of basic type from, converted to t when needed.
+func (g *generator) convert(t types.Type, from types.BasicKind, expr string) string {
+	if types.Identical(t, types.Typ[from]) {
+		return expr
+	}
+	return g.typeString(t) + "(" + expr + ")"
+}
+
+// addr returns a pointer to the value dst names.
+func addr(dst string) string {
+	if strings.HasPrefix(dst, "*") {
+		return dst[1:]
+	}
+	return "&" + dst
+}
+
+// recv returns dst in a form usable as a method receiver.
+func recv(dst string) string {
+	if strings.HasPrefix(dst, "*") {
+		return dst[1:]
+	}
+	return dst
+}
+
+func (g *generator) meta(tag httprequest.Tag) string {
+	if len(tag.Meta) < 1 {
+		return "nil"
+	}
+
+	keys := make([]string, 0, len(tag.Meta))
+	for k := range tag.Meta {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, len(keys))
+	for i, k := range keys {
+		pairs[i] = fmt.Sprintf("%q: %q", k, tag.Meta[k])
+	}
+
+	lit := "map[string]string{" + strings.Join(pairs, ", ") + "}"
+	key := g.binder + lit
+	if name, ok := g.metas[key]; ok {
+		return name
+	}
+
+	name := g.binder + "Meta" + strconv.Itoa(len(g.metas)+1)
+	g.metas[key] = name
+	g.decls = append(g.decls, fmt.Sprintf("%s = %s\n", name, lit))
+	return name
+}
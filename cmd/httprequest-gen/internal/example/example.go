@@ -0,0 +1,112 @@
+// Package example holds the structs used to check that httprequest-gen
+// binders agree with httprequest.As.
+package example
+
+import (
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"strings"
+	"time"
+)
+
+//go:generate go run ../.. -type=Params,Upload -verify
+
+type (
+	Params struct {
+		Pagination
+		*Filters
+
+		ID       int64         `from:"url-param=id"`
+		Page     int           `from:"url-query=page,min=1,max=5"`
+		Ratio    float32       `from:"url-query=ratio,oneof=1.5|2.5"`
+		Small    uint8         `from:"url-query=small"`
+		Active   *bool         `from:"url-query=active,default=true"`
+		Sort     string        `from:"url-query=sort,required,oneof=name|date"`
+		Since    time.Time     `from:"url-query=since,layout=DateOnly"`
+		Until    *time.Time    `from:"url-query=until,required"`
+		Tags     []string      `from:"url-query=tag,minlen=1,maxlen=3,pattern=^[a-z]+$"`
+		IDs      []int         `from:"url-query=ids,split=comma,min=1"`
+		Point    [2]float64    `from:"url-query=point,split=semicolon,default=0;0"`
+		Status   Status        `from:"url-query=status"`
+		Statuses []Status      `from:"url-query=statuses"`
+		Timeout  time.Duration `from:"url-query=timeout,unit=ms"`
+		Tenant   string        `from:"url-param=tenant|header=X-Tenant|url-query=tenant,required,minlen=2,maxlen=32"`
+		Langs    []string      `from:"header=Accept-Language,default=en"`
+		Session  string        `from:"cookie=session,pattern=^v,default=v0"`
+		Theme    *http.Cookie  `from:"cookie=theme"`
+		Body     *Body         `from:"request-body,max=1MB"`
+		Ignored  int           `from:"-"`
+
+		Created DateRange  `from:"group,prefix=created."`
+		Updated *DateRange `from:"group,prefix=updated."`
+	}
+
+	Upload struct {
+		Title   string                  `from:"form=title"`
+		Count   *int                    `from:"form=count"`
+		Avatar  *multipart.FileHeader   `from:"file=avatar"`
+		Photos  []*multipart.FileHeader `from:"file=photos"`
+		Reader  io.ReadCloser           `from:"file=avatar"`
+		Content []byte                  `from:"file=avatar"`
+
+		*Attachment
+
+		Cover *Image `from:"group,prefix=cover_"`
+	}
+
+	Pagination struct {
+		Limit  int `from:"url-query=limit,default=20,max=100"`
+		Offset int `from:"url-query=offset"`
+	}
+
+	Filters struct {
+		Owner string   `from:"url-query=owner"`
+		Kinds []string `from:"url-query=kind"`
+	}
+
+	DateRange struct {
+		From time.Time  `from:"url-query=from,layout=DateOnly"`
+		To   *time.Time `from:"url-query=to,layout=DateOnly"`
+	}
+
+	Image struct {
+		Caption string                `from:"form=caption"`
+		File    *multipart.FileHeader `from:"file=file"`
+	}
+
+	Attachment struct {
+		Extra io.ReadCloser `from:"file=extra"`
+	}
+
+	Body struct {
+		Name string `json:"name"`
+	}
+
+	Status string
+)
+
+func (s *Status) UnmarshalText(text []byte) error {
+	switch v := strings.ToLower(string(text)); v {
+	case "open", "closed":
+		*s = Status(v)
+		return nil
+	default:
+		return errors.New("invalid status")
+	}
+}
+
+func (p *Params) ValidateRequest(req *http.Request) error {
+	if p.Until != nil && p.Until.Before(p.Since) {
+		return errors.New("since is after until")
+	}
+	return nil
+}
+
+func (b *Body) Validate() error {
+	if b.Name == "" {
+		return errors.New("name is required")
+	}
+	return nil
+}
@@ -0,0 +1,165 @@
+package example
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jlucasnsilva/httprequest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newParamsRequest(t testing.TB) *http.Request {
+	req, err := http.NewRequest("POST", "/items?page=2&sort=name&ids=1,2&point=1.5;2&status=open&timeout=250&until=2024-01-02T03:04:05Z", strings.NewReader(`{"name":"x"}`))
+	require.Nil(t, err)
+
+	req.SetPathValue("id", "42")
+	req.Header.Set("X-Tenant", "acme")
+	req.AddCookie(&http.Cookie{Name: "session", Value: "v1"})
+	return req
+}
+
+func TestBindParamsOptions(t *testing.T) {
+	durationConv := httprequest.WithConverter(reflect.TypeOf(time.Duration(0)), func(s string, meta map[string]string) (any, error) {
+		return time.ParseDuration(s + meta["unit"])
+	})
+
+	t.Run("should succeed with converters", func(t *testing.T) {
+		var generated, reflective Params
+
+		genErr := BindParams(newParamsRequest(t), &generated, durationConv)
+		asErr := httprequest.As(newParamsRequest(t), &reflective, durationConv)
+
+		require.Nil(t, genErr)
+		require.Nil(t, asErr)
+		assert.Equal(t, reflective, generated)
+		assert.Equal(t, int64(42), generated.ID)
+		assert.Equal(t, []int{1, 2}, generated.IDs)
+		assert.Equal(t, Status("open"), generated.Status)
+		assert.Equal(t, 250*time.Millisecond, generated.Timeout)
+		assert.Equal(t, &Body{Name: "x"}, generated.Body)
+		assert.Equal(t, 20, generated.Limit)
+		assert.Equal(t, []string{"en"}, generated.Langs)
+		assert.Equal(t, "v1", generated.Session)
+	})
+
+	t.Run("should use the options of a binder", func(t *testing.T) {
+		var generated, reflective Params
+
+		binder := httprequest.New(durationConv)
+		genErr := BindParamsWith(binder, newParamsRequest(t), &generated)
+		asErr := binder.As(newParamsRequest(t), &reflective)
+
+		require.Nil(t, genErr)
+		require.Nil(t, asErr)
+		assert.Equal(t, reflective, generated)
+		assert.Equal(t, 250*time.Millisecond, generated.Timeout)
+	})
+
+	t.Run("should fail fast like As", func(t *testing.T) {
+		var generated, reflective Params
+
+		req := func() *http.Request {
+			req, err := http.NewRequest("GET", "/items?page=x&ratio=y", nil)
+			require.Nil(t, err)
+			return req
+		}
+
+		genErr := BindParams(req(), &generated, httprequest.WithFailFast())
+		asErr := httprequest.As(req(), &reflective, httprequest.WithFailFast())
+
+		var errs httprequest.BindingErrors
+		require.True(t, errors.As(genErr, &errs))
+		assert.Len(t, errs, 1)
+		assert.Equal(t, asErr.Error(), genErr.Error())
+	})
+
+	t.Run("should validate like As", func(t *testing.T) {
+		var generated, reflective Params
+
+		req := func() *http.Request {
+			req, err := http.NewRequest("GET", "/items?page=9&ratio=3&sort=size&tag=a&tag=B&ids=2,0", nil)
+			require.Nil(t, err)
+
+			req.Header.Set("X-Tenant", "a")
+			req.AddCookie(&http.Cookie{Name: "session", Value: "s3cr3t"})
+			return req
+		}
+
+		genErr := BindParams(req(), &generated)
+		asErr := httprequest.As(req(), &reflective)
+
+		var errs httprequest.BindingErrors
+		require.True(t, errors.As(genErr, &errs))
+		assert.Equal(t, asErr.Error(), genErr.Error())
+		for _, target := range []error{
+			httprequest.ErrMax,
+			httprequest.ErrOneOf,
+			httprequest.ErrPattern,
+			httprequest.ErrMin,
+			httprequest.ErrMinLen,
+			httprequest.ErrRequired,
+		} {
+			assert.ErrorIs(t, genErr, target)
+		}
+	})
+
+	t.Run("should run validators like As", func(t *testing.T) {
+		var generated, reflective Params
+
+		errTenant := errors.New("unknown tenant")
+		validator := httprequest.WithValidator(func(req *http.Request, obj any) error {
+			if obj.(*Params).Tenant != "acme" {
+				return errTenant
+			}
+			return nil
+		})
+
+		req := func() *http.Request {
+			req := newParamsRequest(t)
+			req.Header.Set("X-Tenant", "other")
+			req.URL.RawQuery += "&since=2025-01-01"
+			return req
+		}
+
+		genErr := BindParams(req(), &generated, durationConv, validator)
+		asErr := httprequest.As(req(), &reflective, durationConv, validator)
+
+		var errs httprequest.BindingErrors
+		require.True(t, errors.As(genErr, &errs))
+		require.Len(t, errs, 2)
+		assert.Equal(t, asErr.Error(), genErr.Error())
+		assert.Equal(t, "since is after until", errs[0].Error())
+		assert.ErrorIs(t, errs[1], errTenant)
+	})
+
+	t.Run("should fail with a nil target", func(t *testing.T) {
+		err := BindParams(newParamsRequest(t), nil)
+
+		assert.ErrorIs(t, err, httprequest.ErrInvalidTarget)
+	})
+}
+
+func BenchmarkBindParams(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var obj Params
+		if err := BindParams(newParamsRequest(b), &obj); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkAsParams(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var obj Params
+		if err := httprequest.As(newParamsRequest(b), &obj); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
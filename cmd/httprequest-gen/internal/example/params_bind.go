@@ -0,0 +1,1083 @@
+// Code generated by httprequest-gen; DO NOT EDIT.
+
+package example
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/jlucasnsilva/httprequest"
+)
+
+func BindParams(req *http.Request, obj *Params, opts ...httprequest.Option) error {
+	return bindParams(httprequest.NewSources(req, opts...), obj)
+}
+
+// BindParamsWith is BindParams with the options and plans of b.
+func BindParamsWith(b *httprequest.Binder, req *http.Request, obj *Params, opts ...httprequest.Option) error {
+	return bindParams(b.Sources(req, opts...), obj)
+}
+
+func bindParams(s *httprequest.Sources, obj *Params) error {
+	if obj == nil {
+		return fmt.Errorf("%w: %T", httprequest.ErrInvalidTarget, obj)
+	}
+
+	var errs httprequest.BindingErrors
+
+	fail := func(field, kind, source, value string, err error, tried ...httprequest.TagSource) {
+		errs = append(errs, &httprequest.FieldError{Field: field, Kind: kind, Source: source, Value: value, Err: err, Tried: tried})
+	}
+
+	// body is set once the request body is bound.
+	var body any
+
+	// Pagination.Limit
+	if params, err := s.Values("url-query", "limit"); err != nil {
+		fail("Pagination.Limit", "url-query", "limit", "", err)
+	} else {
+		if len(params) < 1 || (len(params) == 1 && params[0] == "") {
+			params = []string{"20"}
+		}
+		if err := func() error {
+			if ok, err := s.Convert(&obj.Pagination.Limit, params[0], bindParamsMeta1); ok {
+				if err != nil {
+					return err
+				}
+			} else {
+				if v, err := strconv.ParseInt(params[0], 10, 64); err != nil {
+					return err
+				} else {
+					obj.Pagination.Limit = int(v)
+				}
+			}
+			if float64(obj.Pagination.Limit) > 100 {
+				return fmt.Errorf("%w (max=100)", httprequest.ErrMax)
+			}
+			return nil
+		}(); err != nil {
+			fail("Pagination.Limit", "url-query", "limit", strings.Join(params, ","), err)
+		}
+	}
+	if len(errs) > 0 && s.FailFast() {
+		return errs
+	}
+
+	// Pagination.Offset
+	if params, err := s.Values("url-query", "offset"); err != nil {
+		fail("Pagination.Offset", "url-query", "offset", "", err)
+	} else if len(params) > 1 || (len(params) == 1 && params[0] != "") {
+		if err := func() error {
+			if ok, err := s.Convert(&obj.Pagination.Offset, params[0], nil); ok {
+				if err != nil {
+					return err
+				}
+			} else {
+				if v, err := strconv.ParseInt(params[0], 10, 64); err != nil {
+					return err
+				} else {
+					obj.Pagination.Offset = int(v)
+				}
+			}
+			return nil
+		}(); err != nil {
+			fail("Pagination.Offset", "url-query", "offset", strings.Join(params, ","), err)
+		}
+	}
+	if len(errs) > 0 && s.FailFast() {
+		return errs
+	}
+
+	// Filters.Owner
+	if params, err := s.Values("url-query", "owner"); err != nil {
+		fail("Filters.Owner", "url-query", "owner", "", err)
+	} else if len(params) > 1 || (len(params) == 1 && params[0] != "") {
+		if err := func() error {
+			if obj.Filters == nil {
+				obj.Filters = new(Filters)
+			}
+			if ok, err := s.Convert(&obj.Filters.Owner, params[0], nil); ok {
+				if err != nil {
+					return err
+				}
+			} else {
+				obj.Filters.Owner = params[0]
+			}
+			return nil
+		}(); err != nil {
+			fail("Filters.Owner", "url-query", "owner", strings.Join(params, ","), err)
+		}
+	}
+	if len(errs) > 0 && s.FailFast() {
+		return errs
+	}
+
+	// Filters.Kinds
+	if params, err := s.Values("url-query", "kind"); err != nil {
+		fail("Filters.Kinds", "url-query", "kind", "", err)
+	} else if len(params) > 1 || (len(params) == 1 && params[0] != "") {
+		if err := func() error {
+			if obj.Filters == nil {
+				obj.Filters = new(Filters)
+			}
+			if ok, err := s.Convert(&obj.Filters.Kinds, params[0], nil); ok {
+				if err != nil {
+					return err
+				}
+			} else {
+				s1 := make([]string, len(params))
+				for i2 := range params {
+					if err := func() error {
+						if ok, err := s.Convert(&s1[i2], params[i2], nil); ok {
+							return err
+						}
+						s1[i2] = params[i2]
+						return nil
+					}(); err != nil {
+						return fmt.Errorf("element %d: %w", i2, err)
+					}
+				}
+				obj.Filters.Kinds = s1
+			}
+			return nil
+		}(); err != nil {
+			fail("Filters.Kinds", "url-query", "kind", strings.Join(params, ","), err)
+		}
+	}
+	if len(errs) > 0 && s.FailFast() {
+		return errs
+	}
+
+	// ID
+	if params, err := s.Values("url-param", "id"); err != nil {
+		fail("ID", "url-param", "id", "", err)
+	} else if len(params) > 1 || (len(params) == 1 && params[0] != "") {
+		if err := func() error {
+			if ok, err := s.Convert(&obj.ID, params[0], nil); ok {
+				if err != nil {
+					return err
+				}
+			} else {
+				if v, err := strconv.ParseInt(params[0], 10, 64); err != nil {
+					return err
+				} else {
+					obj.ID = v
+				}
+			}
+			return nil
+		}(); err != nil {
+			fail("ID", "url-param", "id", strings.Join(params, ","), err)
+		}
+	}
+	if len(errs) > 0 && s.FailFast() {
+		return errs
+	}
+
+	// Page
+	if params, err := s.Values("url-query", "page"); err != nil {
+		fail("Page", "url-query", "page", "", err)
+	} else if len(params) > 1 || (len(params) == 1 && params[0] != "") {
+		if err := func() error {
+			if ok, err := s.Convert(&obj.Page, params[0], bindParamsMeta2); ok {
+				if err != nil {
+					return err
+				}
+			} else {
+				if v, err := strconv.ParseInt(params[0], 10, 64); err != nil {
+					return err
+				} else {
+					obj.Page = int(v)
+				}
+			}
+			if float64(obj.Page) < 1 {
+				return fmt.Errorf("%w (min=1)", httprequest.ErrMin)
+			}
+			if float64(obj.Page) > 5 {
+				return fmt.Errorf("%w (max=5)", httprequest.ErrMax)
+			}
+			return nil
+		}(); err != nil {
+			fail("Page", "url-query", "page", strings.Join(params, ","), err)
+		}
+	}
+	if len(errs) > 0 && s.FailFast() {
+		return errs
+	}
+
+	// Ratio
+	if params, err := s.Values("url-query", "ratio"); err != nil {
+		fail("Ratio", "url-query", "ratio", "", err)
+	} else if len(params) > 1 || (len(params) == 1 && params[0] != "") {
+		if err := func() error {
+			if ok, err := s.Convert(&obj.Ratio, params[0], bindParamsMeta3); ok {
+				if err != nil {
+					return err
+				}
+			} else {
+				if v, err := strconv.ParseFloat(params[0], 32); err != nil {
+					return err
+				} else {
+					obj.Ratio = float32(v)
+				}
+			}
+			if float64(obj.Ratio) != 1.5 && float64(obj.Ratio) != 2.5 {
+				return fmt.Errorf("%w (oneof=1.5|2.5)", httprequest.ErrOneOf)
+			}
+			return nil
+		}(); err != nil {
+			fail("Ratio", "url-query", "ratio", strings.Join(params, ","), err)
+		}
+	}
+	if len(errs) > 0 && s.FailFast() {
+		return errs
+	}
+
+	// Small
+	if params, err := s.Values("url-query", "small"); err != nil {
+		fail("Small", "url-query", "small", "", err)
+	} else if len(params) > 1 || (len(params) == 1 && params[0] != "") {
+		if err := func() error {
+			if ok, err := s.Convert(&obj.Small, params[0], nil); ok {
+				if err != nil {
+					return err
+				}
+			} else {
+				if v, err := strconv.ParseUint(params[0], 10, 8); err != nil {
+					return err
+				} else {
+					obj.Small = uint8(v)
+				}
+			}
+			return nil
+		}(); err != nil {
+			fail("Small", "url-query", "small", strings.Join(params, ","), err)
+		}
+	}
+	if len(errs) > 0 && s.FailFast() {
+		return errs
+	}
+
+	// Active
+	if params, err := s.Values("url-query", "active"); err != nil {
+		fail("Active", "url-query", "active", "", err)
+	} else {
+		if len(params) < 1 {
+			params = []string{"true"}
+		}
+		if err := func() error {
+			if ok, err := s.Convert(&obj.Active, params[0], bindParamsMeta4); ok {
+				if err != nil {
+					return err
+				}
+			} else {
+				p1 := new(bool)
+				if ok, err := s.Convert(p1, params[0], bindParamsMeta4); ok {
+					if err != nil {
+						return err
+					}
+				} else {
+					if v, err := strconv.ParseBool(params[0]); err != nil {
+						return err
+					} else {
+						*p1 = v
+					}
+				}
+				obj.Active = p1
+			}
+			return nil
+		}(); err != nil {
+			fail("Active", "url-query", "active", strings.Join(params, ","), err)
+		}
+	}
+	if len(errs) > 0 && s.FailFast() {
+		return errs
+	}
+
+	// Sort
+	if params, err := s.Values("url-query", "sort"); err != nil {
+		fail("Sort", "url-query", "sort", "", err)
+	} else if len(params) > 1 || (len(params) == 1 && params[0] != "") {
+		if err := func() error {
+			if ok, err := s.Convert(&obj.Sort, params[0], bindParamsMeta5); ok {
+				if err != nil {
+					return err
+				}
+			} else {
+				obj.Sort = params[0]
+			}
+			if obj.Sort != "name" && obj.Sort != "date" {
+				return fmt.Errorf("%w (oneof=name|date)", httprequest.ErrOneOf)
+			}
+			return nil
+		}(); err != nil {
+			fail("Sort", "url-query", "sort", strings.Join(params, ","), err)
+		}
+	} else {
+		fail("Sort", "url-query", "sort", "", httprequest.ErrRequired)
+	}
+	if len(errs) > 0 && s.FailFast() {
+		return errs
+	}
+
+	// Since
+	if params, err := s.Values("url-query", "since"); err != nil {
+		fail("Since", "url-query", "since", "", err)
+	} else if len(params) > 1 || (len(params) == 1 && params[0] != "") {
+		if err := func() error {
+			if ok, err := s.Convert(&obj.Since, params[0], bindParamsMeta6); ok {
+				if err != nil {
+					return err
+				}
+			} else {
+				if v, err := time.Parse("2006-01-02", params[0]); err != nil {
+					return err
+				} else {
+					obj.Since = v
+				}
+			}
+			return nil
+		}(); err != nil {
+			fail("Since", "url-query", "since", strings.Join(params, ","), err)
+		}
+	}
+	if len(errs) > 0 && s.FailFast() {
+		return errs
+	}
+
+	// Until
+	if params, err := s.Values("url-query", "until"); err != nil {
+		fail("Until", "url-query", "until", "", err)
+	} else if len(params) > 0 {
+		if err := func() error {
+			if ok, err := s.Convert(&obj.Until, params[0], bindParamsMeta7); ok {
+				if err != nil {
+					return err
+				}
+			} else {
+				p1 := new(time.Time)
+				if ok, err := s.Convert(p1, params[0], bindParamsMeta7); ok {
+					if err != nil {
+						return err
+					}
+				} else {
+					if v, err := time.Parse("2006-01-02T15:04:05Z07:00", params[0]); err != nil {
+						return err
+					} else {
+						*p1 = v
+					}
+				}
+				obj.Until = p1
+			}
+			return nil
+		}(); err != nil {
+			fail("Until", "url-query", "until", strings.Join(params, ","), err)
+		}
+	} else {
+		fail("Until", "url-query", "until", "", httprequest.ErrRequired)
+	}
+	if len(errs) > 0 && s.FailFast() {
+		return errs
+	}
+
+	// Tags
+	if params, err := s.Values("url-query", "tag"); err != nil {
+		fail("Tags", "url-query", "tag", "", err)
+	} else if len(params) > 1 || (len(params) == 1 && params[0] != "") {
+		if err := func() error {
+			if ok, err := s.Convert(&obj.Tags, params[0], bindParamsMeta8); ok {
+				if err != nil {
+					return err
+				}
+			} else {
+				s1 := make([]string, len(params))
+				for i2 := range params {
+					if err := func() error {
+						if ok, err := s.Convert(&s1[i2], params[i2], bindParamsMeta8); ok {
+							return err
+						}
+						s1[i2] = params[i2]
+						return nil
+					}(); err != nil {
+						return fmt.Errorf("element %d: %w", i2, err)
+					}
+				}
+				obj.Tags = s1
+			}
+			if len(obj.Tags) < 1 {
+				return fmt.Errorf("%w (minlen=1)", httprequest.ErrMinLen)
+			}
+			if len(obj.Tags) > 3 {
+				return fmt.Errorf("%w (maxlen=3)", httprequest.ErrMaxLen)
+			}
+			for i3, e4 := range obj.Tags {
+				if !bindParamsPattern1.MatchString(e4) {
+					return fmt.Errorf("element %d: %w (pattern=^[a-z]+$)", i3, httprequest.ErrPattern)
+				}
+			}
+			return nil
+		}(); err != nil {
+			fail("Tags", "url-query", "tag", strings.Join(params, ","), err)
+		}
+	}
+	if len(errs) > 0 && s.FailFast() {
+		return errs
+	}
+
+	// IDs
+	if params, err := s.Values("url-query", "ids"); err != nil {
+		fail("IDs", "url-query", "ids", "", err)
+	} else if len(params) > 1 || (len(params) == 1 && params[0] != "") {
+		if err := func() error {
+			split1 := make([]string, 0, len(params))
+			for _, p := range params {
+				split1 = append(split1, strings.Split(p, ",")...)
+			}
+			if ok, err := s.Convert(&obj.IDs, split1[0], bindParamsMeta9); ok {
+				if err != nil {
+					return err
+				}
+			} else {
+				s2 := make([]int, len(split1))
+				for i3 := range split1 {
+					if err := func() error {
+						if ok, err := s.Convert(&s2[i3], split1[i3], bindParamsMeta9); ok {
+							return err
+						}
+						if v, err := strconv.ParseInt(split1[i3], 10, 64); err != nil {
+							return err
+						} else {
+							s2[i3] = int(v)
+						}
+						return nil
+					}(); err != nil {
+						return fmt.Errorf("element %d: %w", i3, err)
+					}
+				}
+				obj.IDs = s2
+			}
+			for i4, e5 := range obj.IDs {
+				if float64(e5) < 1 {
+					return fmt.Errorf("element %d: %w (min=1)", i4, httprequest.ErrMin)
+				}
+			}
+			return nil
+		}(); err != nil {
+			fail("IDs", "url-query", "ids", strings.Join(params, ","), err)
+		}
+	}
+	if len(errs) > 0 && s.FailFast() {
+		return errs
+	}
+
+	// Point
+	if params, err := s.Values("url-query", "point"); err != nil {
+		fail("Point", "url-query", "point", "", err)
+	} else {
+		if len(params) < 1 || (len(params) == 1 && params[0] == "") {
+			params = []string{"0;0"}
+		}
+		if err := func() error {
+			split1 := make([]string, 0, len(params))
+			for _, p := range params {
+				split1 = append(split1, strings.Split(p, ";")...)
+			}
+			if ok, err := s.Convert(&obj.Point, split1[0], bindParamsMeta10); ok {
+				if err != nil {
+					return err
+				}
+			} else {
+				if len(split1) != 2 {
+					return fmt.Errorf("%w: got %d values, want %d", httprequest.ErrArrayLength, len(split1), 2)
+				}
+				var a2 [2]float64
+				for i3 := range split1 {
+					if err := func() error {
+						if ok, err := s.Convert(&a2[i3], split1[i3], bindParamsMeta10); ok {
+							return err
+						}
+						if v, err := strconv.ParseFloat(split1[i3], 64); err != nil {
+							return err
+						} else {
+							a2[i3] = v
+						}
+						return nil
+					}(); err != nil {
+						return fmt.Errorf("element %d: %w", i3, err)
+					}
+				}
+				obj.Point = a2
+			}
+			return nil
+		}(); err != nil {
+			fail("Point", "url-query", "point", strings.Join(params, ","), err)
+		}
+	}
+	if len(errs) > 0 && s.FailFast() {
+		return errs
+	}
+
+	// Status
+	if params, err := s.Values("url-query", "status"); err != nil {
+		fail("Status", "url-query", "status", "", err)
+	} else if len(params) > 1 || (len(params) == 1 && params[0] != "") {
+		if err := func() error {
+			if ok, err := s.Convert(&obj.Status, params[0], nil); ok {
+				if err != nil {
+					return err
+				}
+			} else {
+				if err := obj.Status.UnmarshalText([]byte(params[0])); err != nil {
+					return err
+				}
+			}
+			return nil
+		}(); err != nil {
+			fail("Status", "url-query", "status", strings.Join(params, ","), err)
+		}
+	}
+	if len(errs) > 0 && s.FailFast() {
+		return errs
+	}
+
+	// Statuses
+	if params, err := s.Values("url-query", "statuses"); err != nil {
+		fail("Statuses", "url-query", "statuses", "", err)
+	} else if len(params) > 1 || (len(params) == 1 && params[0] != "") {
+		if err := func() error {
+			if ok, err := s.Convert(&obj.Statuses, params[0], nil); ok {
+				if err != nil {
+					return err
+				}
+			} else {
+				s1 := make([]Status, len(params))
+				for i2 := range params {
+					if err := func() error {
+						if ok, err := s.Convert(&s1[i2], params[i2], nil); ok {
+							return err
+						}
+						if err := s1[i2].UnmarshalText([]byte(params[i2])); err != nil {
+							return err
+						}
+						return nil
+					}(); err != nil {
+						return fmt.Errorf("element %d: %w", i2, err)
+					}
+				}
+				obj.Statuses = s1
+			}
+			return nil
+		}(); err != nil {
+			fail("Statuses", "url-query", "statuses", strings.Join(params, ","), err)
+		}
+	}
+	if len(errs) > 0 && s.FailFast() {
+		return errs
+	}
+
+	// Timeout
+	if params, err := s.Values("url-query", "timeout"); err != nil {
+		fail("Timeout", "url-query", "timeout", "", err)
+	} else if len(params) > 1 || (len(params) == 1 && params[0] != "") {
+		if err := func() error {
+			if ok, err := s.Convert(&obj.Timeout, params[0], bindParamsMeta11); ok {
+				if err != nil {
+					return err
+				}
+			} else {
+				if v, err := strconv.ParseInt(params[0], 10, 64); err != nil {
+					return err
+				} else {
+					obj.Timeout = time.Duration(v)
+				}
+			}
+			return nil
+		}(); err != nil {
+			fail("Timeout", "url-query", "timeout", strings.Join(params, ","), err)
+		}
+	}
+	if len(errs) > 0 && s.FailFast() {
+		return errs
+	}
+
+	// Tenant
+	if src, params, tried, err := s.First(bindParamsChain1, false); err != nil {
+		fail("Tenant", src.Kind, src.Source, "", err, tried...)
+	} else if len(params) > 0 {
+		if err := func() error {
+			if ok, err := s.Convert(&obj.Tenant, params[0], bindParamsMeta12); ok {
+				if err != nil {
+					return err
+				}
+			} else {
+				obj.Tenant = params[0]
+			}
+			if utf8.RuneCountInString(obj.Tenant) < 2 {
+				return fmt.Errorf("%w (minlen=2)", httprequest.ErrMinLen)
+			}
+			if utf8.RuneCountInString(obj.Tenant) > 32 {
+				return fmt.Errorf("%w (maxlen=32)", httprequest.ErrMaxLen)
+			}
+			return nil
+		}(); err != nil {
+			fail("Tenant", src.Kind, src.Source, strings.Join(params, ","), err, tried...)
+		}
+	} else {
+		fail("Tenant", src.Kind, src.Source, "", httprequest.ErrRequired, tried...)
+	}
+	if len(errs) > 0 && s.FailFast() {
+		return errs
+	}
+
+	// Langs
+	if params, err := s.Values("header", "Accept-Language"); err != nil {
+		fail("Langs", "header", "Accept-Language", "", err)
+	} else {
+		if len(params) < 1 || (len(params) == 1 && params[0] == "") {
+			params = []string{"en"}
+		}
+		if err := func() error {
+			if ok, err := s.Convert(&obj.Langs, params[0], bindParamsMeta13); ok {
+				if err != nil {
+					return err
+				}
+			} else {
+				s1 := make([]string, len(params))
+				for i2 := range params {
+					if err := func() error {
+						if ok, err := s.Convert(&s1[i2], params[i2], bindParamsMeta13); ok {
+							return err
+						}
+						s1[i2] = params[i2]
+						return nil
+					}(); err != nil {
+						return fmt.Errorf("element %d: %w", i2, err)
+					}
+				}
+				obj.Langs = s1
+			}
+			return nil
+		}(); err != nil {
+			fail("Langs", "header", "Accept-Language", strings.Join(params, ","), err)
+		}
+	}
+	if len(errs) > 0 && s.FailFast() {
+		return errs
+	}
+
+	// Session
+	if c, err := s.Cookie("session"); err != nil && !errors.Is(err, http.ErrNoCookie) {
+		fail("Session", "cookie", "session", "", err)
+	} else if err := func() error {
+		if err != nil {
+			c = &http.Cookie{Name: "session", Value: "v0"}
+		}
+		params := []string{c.Value}
+		if ok, err := s.Convert(&obj.Session, params[0], bindParamsMeta14); ok {
+			if err != nil {
+				return err
+			}
+		} else {
+			obj.Session = params[0]
+		}
+		if !bindParamsPattern2.MatchString(obj.Session) {
+			return fmt.Errorf("%w (pattern=^v)", httprequest.ErrPattern)
+		}
+		return nil
+	}(); err != nil {
+		fail("Session", "cookie", "session", c.Value, err)
+	}
+	if len(errs) > 0 && s.FailFast() {
+		return errs
+	}
+
+	// Theme
+	if c, err := s.Cookie("theme"); err != nil {
+		if !errors.Is(err, http.ErrNoCookie) {
+			fail("Theme", "cookie", "theme", "", err)
+		}
+	} else if err := func() error {
+		obj.Theme = c
+		return nil
+	}(); err != nil {
+		fail("Theme", "cookie", "theme", c.Value, err)
+	}
+	if len(errs) > 0 && s.FailFast() {
+		return errs
+	}
+
+	// Body
+	if obj.Body == nil {
+		obj.Body = new(Body)
+	}
+	if err := s.DecodeMax(obj.Body, 1048576); err != nil {
+		fail("Body", "request-body", "", "", err)
+	} else {
+		body = obj.Body
+	}
+	if len(errs) > 0 && s.FailFast() {
+		return errs
+	}
+
+	// Created.From
+	if params, err := s.Values("url-query", "created.from"); err != nil {
+		fail("Created.From", "url-query", "created.from", "", err)
+	} else if len(params) > 1 || (len(params) == 1 && params[0] != "") {
+		if err := func() error {
+			if ok, err := s.Convert(&obj.Created.From, params[0], bindParamsMeta6); ok {
+				if err != nil {
+					return err
+				}
+			} else {
+				if v, err := time.Parse("2006-01-02", params[0]); err != nil {
+					return err
+				} else {
+					obj.Created.From = v
+				}
+			}
+			return nil
+		}(); err != nil {
+			fail("Created.From", "url-query", "created.from", strings.Join(params, ","), err)
+		}
+	}
+	if len(errs) > 0 && s.FailFast() {
+		return errs
+	}
+
+	// Created.To
+	if params, err := s.Values("url-query", "created.to"); err != nil {
+		fail("Created.To", "url-query", "created.to", "", err)
+	} else if len(params) > 0 {
+		if err := func() error {
+			if ok, err := s.Convert(&obj.Created.To, params[0], bindParamsMeta6); ok {
+				if err != nil {
+					return err
+				}
+			} else {
+				p1 := new(time.Time)
+				if ok, err := s.Convert(p1, params[0], bindParamsMeta6); ok {
+					if err != nil {
+						return err
+					}
+				} else {
+					if v, err := time.Parse("2006-01-02", params[0]); err != nil {
+						return err
+					} else {
+						*p1 = v
+					}
+				}
+				obj.Created.To = p1
+			}
+			return nil
+		}(); err != nil {
+			fail("Created.To", "url-query", "created.to", strings.Join(params, ","), err)
+		}
+	}
+	if len(errs) > 0 && s.FailFast() {
+		return errs
+	}
+
+	// Updated.From
+	if params, err := s.Values("url-query", "updated.from"); err != nil {
+		fail("Updated.From", "url-query", "updated.from", "", err)
+	} else if len(params) > 1 || (len(params) == 1 && params[0] != "") {
+		if err := func() error {
+			if obj.Updated == nil {
+				obj.Updated = new(DateRange)
+			}
+			if ok, err := s.Convert(&obj.Updated.From, params[0], bindParamsMeta6); ok {
+				if err != nil {
+					return err
+				}
+			} else {
+				if v, err := time.Parse("2006-01-02", params[0]); err != nil {
+					return err
+				} else {
+					obj.Updated.From = v
+				}
+			}
+			return nil
+		}(); err != nil {
+			fail("Updated.From", "url-query", "updated.from", strings.Join(params, ","), err)
+		}
+	}
+	if len(errs) > 0 && s.FailFast() {
+		return errs
+	}
+
+	// Updated.To
+	if params, err := s.Values("url-query", "updated.to"); err != nil {
+		fail("Updated.To", "url-query", "updated.to", "", err)
+	} else if len(params) > 0 {
+		if err := func() error {
+			if obj.Updated == nil {
+				obj.Updated = new(DateRange)
+			}
+			if ok, err := s.Convert(&obj.Updated.To, params[0], bindParamsMeta6); ok {
+				if err != nil {
+					return err
+				}
+			} else {
+				p1 := new(time.Time)
+				if ok, err := s.Convert(p1, params[0], bindParamsMeta6); ok {
+					if err != nil {
+						return err
+					}
+				} else {
+					if v, err := time.Parse("2006-01-02", params[0]); err != nil {
+						return err
+					} else {
+						*p1 = v
+					}
+				}
+				obj.Updated.To = p1
+			}
+			return nil
+		}(); err != nil {
+			fail("Updated.To", "url-query", "updated.to", strings.Join(params, ","), err)
+		}
+	}
+	if len(errs) > 0 && s.FailFast() {
+		return errs
+	}
+
+	if body != nil {
+		errs = s.ValidateBody(errs, "Body", body)
+	}
+	if errs = s.ValidateTarget(errs, obj); len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+func BindUpload(req *http.Request, obj *Upload, opts ...httprequest.Option) error {
+	return bindUpload(httprequest.NewSources(req, opts...), obj)
+}
+
+// BindUploadWith is BindUpload with the options and plans of b.
+func BindUploadWith(b *httprequest.Binder, req *http.Request, obj *Upload, opts ...httprequest.Option) error {
+	return bindUpload(b.Sources(req, opts...), obj)
+}
+
+func bindUpload(s *httprequest.Sources, obj *Upload) error {
+	if obj == nil {
+		return fmt.Errorf("%w: %T", httprequest.ErrInvalidTarget, obj)
+	}
+
+	var errs httprequest.BindingErrors
+
+	fail := func(field, kind, source, value string, err error, tried ...httprequest.TagSource) {
+		errs = append(errs, &httprequest.FieldError{Field: field, Kind: kind, Source: source, Value: value, Err: err, Tried: tried})
+	}
+
+	// Title
+	if params, err := s.Values("form", "title"); err != nil {
+		fail("Title", "form", "title", "", err)
+	} else if len(params) > 1 || (len(params) == 1 && params[0] != "") {
+		if err := func() error {
+			if ok, err := s.Convert(&obj.Title, params[0], nil); ok {
+				if err != nil {
+					return err
+				}
+			} else {
+				obj.Title = params[0]
+			}
+			return nil
+		}(); err != nil {
+			fail("Title", "form", "title", strings.Join(params, ","), err)
+		}
+	}
+	if len(errs) > 0 && s.FailFast() {
+		return errs
+	}
+
+	// Count
+	if params, err := s.Values("form", "count"); err != nil {
+		fail("Count", "form", "count", "", err)
+	} else if len(params) > 0 {
+		if err := func() error {
+			if ok, err := s.Convert(&obj.Count, params[0], nil); ok {
+				if err != nil {
+					return err
+				}
+			} else {
+				p1 := new(int)
+				if ok, err := s.Convert(p1, params[0], nil); ok {
+					if err != nil {
+						return err
+					}
+				} else {
+					if v, err := strconv.ParseInt(params[0], 10, 64); err != nil {
+						return err
+					} else {
+						*p1 = int(v)
+					}
+				}
+				obj.Count = p1
+			}
+			return nil
+		}(); err != nil {
+			fail("Count", "form", "count", strings.Join(params, ","), err)
+		}
+	}
+	if len(errs) > 0 && s.FailFast() {
+		return errs
+	}
+
+	// Avatar
+	if headers, err := s.Files("avatar"); err != nil {
+		fail("Avatar", "file", "avatar", "", err)
+	} else if err := func() error {
+		obj.Avatar = headers[0]
+		return nil
+	}(); err != nil {
+		fail("Avatar", "file", "avatar", "", err)
+	}
+	if len(errs) > 0 && s.FailFast() {
+		return errs
+	}
+
+	// Photos
+	if headers, err := s.Files("photos"); err != nil {
+		fail("Photos", "file", "photos", "", err)
+	} else if err := func() error {
+		obj.Photos = headers
+		return nil
+	}(); err != nil {
+		fail("Photos", "file", "photos", "", err)
+	}
+	if len(errs) > 0 && s.FailFast() {
+		return errs
+	}
+
+	// Reader
+	if headers, err := s.Files("avatar"); err != nil {
+		fail("Reader", "file", "avatar", "", err)
+	} else if err := func() error {
+		file, err := headers[0].Open()
+		if err != nil {
+			return err
+		}
+		obj.Reader = file
+		return nil
+	}(); err != nil {
+		fail("Reader", "file", "avatar", "", err)
+	}
+	if len(errs) > 0 && s.FailFast() {
+		return errs
+	}
+
+	// Content
+	if headers, err := s.Files("avatar"); err != nil {
+		fail("Content", "file", "avatar", "", err)
+	} else if err := func() error {
+		file, err := headers[0].Open()
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+
+		data, err := io.ReadAll(file)
+		if err != nil {
+			return err
+		}
+		obj.Content = data
+		return nil
+	}(); err != nil {
+		fail("Content", "file", "avatar", "", err)
+	}
+	if len(errs) > 0 && s.FailFast() {
+		return errs
+	}
+
+	// Attachment.Extra
+	if headers, err := s.Files("extra"); err != nil {
+		fail("Attachment.Extra", "file", "extra", "", err)
+	} else if err := func() error {
+		if obj.Attachment == nil {
+			obj.Attachment = new(Attachment)
+		}
+		file, err := headers[0].Open()
+		if err != nil {
+			return err
+		}
+		obj.Attachment.Extra = file
+		return nil
+	}(); err != nil {
+		fail("Attachment.Extra", "file", "extra", "", err)
+	}
+	if len(errs) > 0 && s.FailFast() {
+		return errs
+	}
+
+	// Cover.Caption
+	if params, err := s.Values("form", "cover_caption"); err != nil {
+		fail("Cover.Caption", "form", "cover_caption", "", err)
+	} else if len(params) > 1 || (len(params) == 1 && params[0] != "") {
+		if err := func() error {
+			if obj.Cover == nil {
+				obj.Cover = new(Image)
+			}
+			if ok, err := s.Convert(&obj.Cover.Caption, params[0], nil); ok {
+				if err != nil {
+					return err
+				}
+			} else {
+				obj.Cover.Caption = params[0]
+			}
+			return nil
+		}(); err != nil {
+			fail("Cover.Caption", "form", "cover_caption", strings.Join(params, ","), err)
+		}
+	}
+	if len(errs) > 0 && s.FailFast() {
+		return errs
+	}
+
+	// Cover.File
+	if headers, err := s.Files("cover_file"); err != nil {
+		fail("Cover.File", "file", "cover_file", "", err)
+	} else if err := func() error {
+		if obj.Cover == nil {
+			obj.Cover = new(Image)
+		}
+		obj.Cover.File = headers[0]
+		return nil
+	}(); err != nil {
+		fail("Cover.File", "file", "cover_file", "", err)
+	}
+	if len(errs) > 0 && s.FailFast() {
+		return errs
+	}
+
+	if errs = s.ValidateTarget(errs, obj); len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+var (
+	bindParamsMeta1    = map[string]string{"default": "20", "max": "100"}
+	bindParamsMeta2    = map[string]string{"max": "5", "min": "1"}
+	bindParamsMeta3    = map[string]string{"oneof": "1.5|2.5"}
+	bindParamsMeta4    = map[string]string{"default": "true"}
+	bindParamsMeta5    = map[string]string{"oneof": "name|date", "required": ""}
+	bindParamsMeta6    = map[string]string{"layout": "DateOnly"}
+	bindParamsMeta7    = map[string]string{"required": ""}
+	bindParamsMeta8    = map[string]string{"maxlen": "3", "minlen": "1", "pattern": "^[a-z]+$"}
+	bindParamsPattern1 = regexp.MustCompile("^[a-z]+$")
+	bindParamsMeta9    = map[string]string{"min": "1", "split": "comma"}
+	bindParamsMeta10   = map[string]string{"default": "0;0", "split": "semicolon"}
+	bindParamsMeta11   = map[string]string{"unit": "ms"}
+	bindParamsChain1   = []httprequest.TagSource{{Kind: "url-param", Source: "tenant"}, {Kind: "header", Source: "X-Tenant"}, {Kind: "url-query", Source: "tenant"}}
+	bindParamsMeta12   = map[string]string{"maxlen": "32", "minlen": "2", "required": ""}
+	bindParamsMeta13   = map[string]string{"default": "en"}
+	bindParamsMeta14   = map[string]string{"default": "v0", "pattern": "^v"}
+	bindParamsPattern2 = regexp.MustCompile("^v")
+)
@@ -0,0 +1,211 @@
+// Code generated by httprequest-gen; DO NOT EDIT.
+
+package example
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jlucasnsilva/httprequest"
+)
+
+func TestBindParamsMatchesAs(t *testing.T) {
+	for _, mode := range []string{"empty", "valid", "invalid"} {
+		t.Run(mode, func(t *testing.T) {
+			var generated, reflective Params
+
+			genErr := BindParams(newBindParamsRequest(t, mode), &generated)
+			asErr := httprequest.As(newBindParamsRequest(t, mode), &reflective)
+			if fmt.Sprint(genErr) != fmt.Sprint(asErr) {
+				t.Fatalf("errors differ:\ngenerated:      %v\nhttprequest.As: %v", genErr, asErr)
+			}
+			if !reflect.DeepEqual(generated, reflective) {
+				t.Errorf("values differ:\ngenerated:      %+v\nhttprequest.As: %+v", generated, reflective)
+			}
+		})
+	}
+}
+
+func newBindParamsRequest(t *testing.T, mode string) *http.Request {
+	t.Helper()
+
+	var (
+		body        io.Reader
+		contentType string
+
+		query = url.Values{}
+		form  = url.Values{}
+		files = map[string][]string{}
+	)
+
+	pick := func(valid, invalid string) string {
+		if mode == "invalid" {
+			return invalid
+		}
+		return valid
+	}
+	_, _, _ = form, files, pick
+
+	if mode != "empty" {
+		query.Add("limit", pick("7", "invalid"))
+		query.Add("offset", pick("7", "invalid"))
+		query.Add("owner", "value")
+		query.Add("kind", "value")
+		query.Add("kind", "value")
+		query.Add("page", pick("7", "invalid"))
+		query.Add("ratio", pick("1.5", "invalid"))
+		query.Add("small", pick("7", "invalid"))
+		query.Add("active", pick("true", "invalid"))
+		query.Add("sort", "value")
+		query.Add("since", pick(time.Date(2024, time.January, 2, 3, 4, 5, 0, time.UTC).Format("2006-01-02"), "invalid"))
+		query.Add("until", pick(time.Date(2024, time.January, 2, 3, 4, 5, 0, time.UTC).Format("2006-01-02T15:04:05Z07:00"), "invalid"))
+		query.Add("tag", "value")
+		query.Add("tag", "value")
+		query.Add("ids", pick("7", "invalid")+","+pick("7", "invalid"))
+		query.Add("point", pick("1.5", "invalid")+";"+pick("1.5", "invalid"))
+		query.Add("status", "value")
+		query.Add("statuses", "value")
+		query.Add("statuses", "value")
+		query.Add("timeout", pick("7", "invalid"))
+		query.Add("created.from", pick(time.Date(2024, time.January, 2, 3, 4, 5, 0, time.UTC).Format("2006-01-02"), "invalid"))
+		query.Add("created.to", pick(time.Date(2024, time.January, 2, 3, 4, 5, 0, time.UTC).Format("2006-01-02"), "invalid"))
+		query.Add("updated.from", pick(time.Date(2024, time.January, 2, 3, 4, 5, 0, time.UTC).Format("2006-01-02"), "invalid"))
+		query.Add("updated.to", pick(time.Date(2024, time.January, 2, 3, 4, 5, 0, time.UTC).Format("2006-01-02"), "invalid"))
+	}
+
+	body, contentType = strings.NewReader("{}"), "application/json"
+
+	req, err := http.NewRequest("POST", "/?"+query.Encode(), body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	if mode != "empty" {
+		req.SetPathValue("id", pick("7", "invalid"))
+		req.SetPathValue("tenant", "value")
+		req.Header.Add("Accept-Language", "value")
+		req.Header.Add("Accept-Language", "value")
+		req.AddCookie(&http.Cookie{Name: "session", Value: "value"})
+		req.AddCookie(&http.Cookie{Name: "theme", Value: "value"})
+	}
+	return req
+}
+
+func TestBindUploadMatchesAs(t *testing.T) {
+	for _, mode := range []string{"empty", "valid", "invalid"} {
+		t.Run(mode, func(t *testing.T) {
+			var generated, reflective Upload
+
+			genErr := BindUpload(newBindUploadRequest(t, mode), &generated)
+			asErr := httprequest.As(newBindUploadRequest(t, mode), &reflective)
+			if fmt.Sprint(genErr) != fmt.Sprint(asErr) {
+				t.Fatalf("errors differ:\ngenerated:      %v\nhttprequest.As: %v", genErr, asErr)
+			}
+			if generated.Reader != nil && reflective.Reader != nil {
+				a, _ := io.ReadAll(generated.Reader)
+				b, _ := io.ReadAll(reflective.Reader)
+				generated.Reader.Close()
+				reflective.Reader.Close()
+				if !bytes.Equal(a, b) {
+					t.Errorf("Reader contents differ: %q != %q", a, b)
+				}
+				generated.Reader, reflective.Reader = nil, nil
+			}
+			if generated.Attachment != nil && reflective.Attachment != nil && generated.Attachment.Extra != nil && reflective.Attachment.Extra != nil {
+				a, _ := io.ReadAll(generated.Attachment.Extra)
+				b, _ := io.ReadAll(reflective.Attachment.Extra)
+				generated.Attachment.Extra.Close()
+				reflective.Attachment.Extra.Close()
+				if !bytes.Equal(a, b) {
+					t.Errorf("Attachment.Extra contents differ: %q != %q", a, b)
+				}
+				generated.Attachment.Extra, reflective.Attachment.Extra = nil, nil
+			}
+			if !reflect.DeepEqual(generated, reflective) {
+				t.Errorf("values differ:\ngenerated:      %+v\nhttprequest.As: %+v", generated, reflective)
+			}
+		})
+	}
+}
+
+func newBindUploadRequest(t *testing.T, mode string) *http.Request {
+	t.Helper()
+
+	var (
+		body        io.Reader
+		contentType string
+
+		query = url.Values{}
+		form  = url.Values{}
+		files = map[string][]string{}
+	)
+
+	pick := func(valid, invalid string) string {
+		if mode == "invalid" {
+			return invalid
+		}
+		return valid
+	}
+	_, _, _ = form, files, pick
+
+	if mode != "empty" {
+		form.Add("title", "value")
+		form.Add("count", pick("7", "invalid"))
+		files["avatar"] = append(files["avatar"], "content")
+		files["photos"] = append(files["photos"], "content")
+		files["avatar"] = append(files["avatar"], "content")
+		files["avatar"] = append(files["avatar"], "content")
+		files["extra"] = append(files["extra"], "content")
+		form.Add("cover_caption", "value")
+		files["cover_file"] = append(files["cover_file"], "content")
+	}
+
+	var buf bytes.Buffer
+
+	w := multipart.NewWriter(&buf)
+	for key, values := range form {
+		for _, v := range values {
+			if err := w.WriteField(key, v); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	for key, contents := range files {
+		for _, content := range contents {
+			part, err := w.CreateFormFile(key, key+".txt")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := io.WriteString(part, content); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	body, contentType = &buf, w.FormDataContentType()
+
+	req, err := http.NewRequest("POST", "/?"+query.Encode(), body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	if mode != "empty" {
+	}
+	return req
+}
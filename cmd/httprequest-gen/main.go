@@ -0,0 +1,77 @@
+// Command httprequest-gen generates reflection-free binders for structs
+// tagged with from tags. It is meant to be run by go generate:
+//
+//	//go:generate go run github.com/jlucasnsilva/httprequest/cmd/httprequest-gen -type=Params
+//
+// For each type T it writes a BindT(*http.Request, *T, ...httprequest.Option)
+// function with the same semantics as httprequest.As. With -verify it also
+// writes a test checking that BindT and httprequest.As agree.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	var (
+		typeNames = flag.String("type", "", "comma-separated list of struct type names; must be set")
+		output    = flag.String("output", "", "output file name; default srcdir/<type>_bind.go")
+		verify    = flag.Bool("verify", false, "also write a test checking the binders agree with httprequest.As")
+	)
+
+	log.SetFlags(0)
+	log.SetPrefix("httprequest-gen: ")
+	flag.Usage = usage
+	flag.Parse()
+
+	if *typeNames == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	dir := "."
+	if args := flag.Args(); len(args) > 0 {
+		dir = args[0]
+	}
+
+	names := strings.Split(*typeNames, ",")
+	out := *output
+	if out == "" {
+		out = filepath.Join(dir, strings.ToLower(names[0])+"_bind.go")
+	}
+
+	g, err := newGenerator(dir)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	src, err := g.generate(names)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(out, src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+
+	if !*verify {
+		return
+	}
+
+	test, err := g.generateVerify(names)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(strings.TrimSuffix(out, ".go")+"_test.go", test, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "Usage: httprequest-gen [flags] -type T [directory]\n")
+	flag.PrintDefaults()
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const exampleDir = "internal/example"
+
+func TestGenerateExample(t *testing.T) {
+	g, err := newGenerator(exampleDir)
+	require.Nil(t, err)
+
+	names := []string{"Params", "Upload"}
+
+	t.Run("binders should match the committed file", func(t *testing.T) {
+		src, err := g.generate(names)
+		require.Nil(t, err)
+
+		expected, err := os.ReadFile(filepath.Join(exampleDir, "params_bind.go"))
+		require.Nil(t, err)
+		assert.Equal(t, string(expected), string(src), "run go generate ./...")
+	})
+
+	t.Run("verify test should match the committed file", func(t *testing.T) {
+		src, err := g.generateVerify(names)
+		require.Nil(t, err)
+
+		expected, err := os.ReadFile(filepath.Join(exampleDir, "params_bind_test.go"))
+		require.Nil(t, err)
+		assert.Equal(t, string(expected), string(src), "run go generate ./...")
+	})
+
+	t.Run("should fail with unknown types", func(t *testing.T) {
+		_, err := g.generate([]string{"Missing"})
+
+		assert.NotNil(t, err)
+	})
+
+	t.Run("should fail with non struct types", func(t *testing.T) {
+		_, err := g.generate([]string{"Status"})
+
+		assert.NotNil(t, err)
+	})
+}
@@ -0,0 +1,209 @@
+package main
+
+import (
+	"fmt"
+	"go/types"
+	"strconv"
+	"strings"
+
+	"github.com/jlucasnsilva/httprequest"
+)
+
+// checkRules mirrors the checks httprequest makes when it compiles the
+// rules of a field of type t.
+func (g *generator) checkRules(kind string, t types.Type, r httprequest.Rules) error {
+	t = deref(t)
+
+	var (
+		elem   = t
+		sized  = isBasic(t, types.IsString)
+		scalar = kind != fileTag && kind != requestBodyTag && !types.Identical(t, g.cookieType)
+	)
+
+	switch u := t.Underlying().(type) {
+	case *types.Slice:
+		sized, elem = true, deref(u.Elem())
+	case *types.Array:
+		sized, elem = true, deref(u.Elem())
+	}
+
+	var (
+		number = scalar && isBasic(elem, types.IsInteger|types.IsFloat)
+		text   = scalar && isBasic(elem, types.IsString)
+	)
+
+	invalid := func(key string) error {
+		return fmt.Errorf("%w: %s does not apply to %s", httprequest.ErrInvalidRule, key, g.typeString(t))
+	}
+
+	switch {
+	case r.Required && (kind == fileTag || kind == requestBodyTag):
+		return fmt.Errorf("%w: required does not apply to %s", httprequest.ErrInvalidRule, kind)
+	case r.Min != nil && !number:
+		return invalid("min")
+	case r.Max != nil && !number:
+		return invalid("max")
+	case r.MinLen != nil && !(scalar && sized):
+		return invalid("minlen")
+	case r.MaxLen != nil && !(scalar && sized):
+		return invalid("maxlen")
+	case r.Pattern != "" && !text:
+		return invalid("pattern")
+	case r.OneOf != nil && !number && !text:
+		return invalid("oneof")
+	}
+
+	if r.OneOf != nil && number {
+		for _, o := range r.OneOf {
+			if _, err := strconv.ParseFloat(o, 64); err != nil {
+				return fmt.Errorf("%w: oneof=%s", httprequest.ErrInvalidRule, strings.Join(r.OneOf, "|"))
+			}
+		}
+	}
+	return nil
+}
+
+func deref(t types.Type) types.Type {
+	for {
+		ptr, ok := t.Underlying().(*types.Pointer)
+		if !ok {
+			return t
+		}
+		t = ptr.Elem()
+	}
+}
+
+func isBasic(t types.Type, info types.BasicInfo) bool {
+	basic, ok := t.Underlying().(*types.Basic)
+	return ok && basic.Info()&info != 0
+}
+
+// emitRules mirrors the rule set check that runs once dst, of type t, is
+// bound.
+func (g *generator) emitRules(dst string, t types.Type, r httprequest.Rules) {
+	if r.Min == nil && r.Max == nil && r.MinLen == nil && r.MaxLen == nil && r.Pattern == "" && r.OneOf == nil {
+		return
+	}
+
+	if ptr, ok := t.Underlying().(*types.Pointer); ok {
+		p := g.tmp("p")
+		g.printf("if %s := %s; %s != nil {\n", p, dst, p)
+		g.emitRules("*"+p, ptr.Elem(), r)
+		g.printf("}\n")
+		return
+	}
+
+	var elem types.Type
+	switch u := t.Underlying().(type) {
+	case *types.Slice:
+		elem = u.Elem()
+	case *types.Array:
+		elem = u.Elem()
+	}
+
+	switch {
+	case elem != nil:
+		g.emitLen("len("+dst+")", r)
+		if r.Min != nil || r.Max != nil || r.Pattern != "" || r.OneOf != nil {
+			i, e := g.tmp("i"), g.tmp("e")
+			g.printf("for %s, %s := range %s {\n", i, e, dst)
+			g.emitValueRules(e, elem, r, i)
+			g.printf("}\n")
+		}
+	case isBasic(t, types.IsString):
+		g.use("unicode/utf8")
+		g.emitLen("utf8.RuneCountInString("+g.as(t, types.String, dst)+")", r)
+		g.emitValueRules(dst, t, r, "")
+	default:
+		g.emitValueRules(dst, t, r, "")
+	}
+}
+
+func (g *generator) emitLen(n string, r httprequest.Rules) {
+	if r.MinLen != nil {
+		g.printf("if %s < %d {\n%s\n}\n", n, *r.MinLen, g.ruleError("", "ErrMinLen", "minlen", strconv.Itoa(*r.MinLen)))
+	}
+	if r.MaxLen != nil {
+		g.printf("if %s > %d {\n%s\n}\n", n, *r.MaxLen, g.ruleError("", "ErrMaxLen", "maxlen", strconv.Itoa(*r.MaxLen)))
+	}
+}
+
+// emitValueRules checks a single value; index names the element being
+// checked, if any.
+func (g *generator) emitValueRules(dst string, t types.Type, r httprequest.Rules, index string) {
+	if ptr, ok := t.Underlying().(*types.Pointer); ok {
+		p := g.tmp("p")
+		g.printf("if %s := %s; %s != nil {\n", p, dst, p)
+		g.emitValueRules("*"+p, ptr.Elem(), r, index)
+		g.printf("}\n")
+		return
+	}
+
+	oneOf := g.ruleError(index, "ErrOneOf", "oneof", strings.Join(r.OneOf, "|"))
+
+	if isBasic(t, types.IsString) {
+		s := g.as(t, types.String, dst)
+		if r.Pattern != "" {
+			g.printf("if !%s.MatchString(%s) {\n%s\n}\n", g.pattern(r.Pattern), s, g.ruleError(index, "ErrPattern", "pattern", r.Pattern))
+		}
+		if r.OneOf != nil {
+			conds := make([]string, len(r.OneOf))
+			for i, o := range r.OneOf {
+				conds[i] = fmt.Sprintf("%s != %q", s, o)
+			}
+			g.printf("if %s {\n%s\n}\n", strings.Join(conds, " && "), oneOf)
+		}
+		return
+	}
+
+	f := g.as(t, types.Float64, dst)
+	if r.Min != nil {
+		bound := strconv.FormatFloat(*r.Min, 'g', -1, 64)
+		g.printf("if %s < %s {\n%s\n}\n", f, bound, g.ruleError(index, "ErrMin", "min", bound))
+	}
+	if r.Max != nil {
+		bound := strconv.FormatFloat(*r.Max, 'g', -1, 64)
+		g.printf("if %s > %s {\n%s\n}\n", f, bound, g.ruleError(index, "ErrMax", "max", bound))
+	}
+	if r.OneOf != nil {
+		conds := make([]string, len(r.OneOf))
+		for i, o := range r.OneOf {
+			v, _ := strconv.ParseFloat(o, 64)
+			conds[i] = fmt.Sprintf("%s != %s", f, strconv.FormatFloat(v, 'g', -1, 64))
+		}
+		g.printf("if %s {\n%s\n}\n", strings.Join(conds, " && "), oneOf)
+	}
+}
+
+// as returns expr, of type t, converted to the basic type kind when needed.
+func (g *generator) as(t types.Type, kind types.BasicKind, expr string) string {
+	if types.Identical(t, types.Typ[kind]) {
+		return expr
+	}
+	return types.Typ[kind].Name() + "(" + expr + ")"
+}
+
+// ruleError returns the statement returning the error for a failed rule,
+// formatted like httprequest formats it.
+func (g *generator) ruleError(index, sentinel, key, arg string) string {
+	g.use("fmt")
+	format := "%w (" + key + "=" + strings.ReplaceAll(arg, "%", "%%") + ")"
+	if index == "" {
+		return fmt.Sprintf("return fmt.Errorf(%q, httprequest.%s)", format, sentinel)
+	}
+	return fmt.Sprintf("return fmt.Errorf(%q, %s, httprequest.%s)", "element %d: "+format, index, sentinel)
+}
+
+// pattern returns the package-level variable holding the compiled pattern.
+func (g *generator) pattern(expr string) string {
+	key := g.binder + expr
+	if name, ok := g.patterns[key]; ok {
+		return name
+	}
+
+	g.use("regexp")
+	name := g.binder + "Pattern" + strconv.Itoa(len(g.patterns)+1)
+	g.patterns[key] = name
+	g.decls = append(g.decls, fmt.Sprintf("%s = regexp.MustCompile(%q)\n", name, expr))
+	return name
+}
@@ -0,0 +1,221 @@
+package main
+
+import (
+	"fmt"
+	"go/types"
+	"strings"
+
+	"github.com/jlucasnsilva/httprequest"
+)
+
+func (g *generator) generateVerify(names []string) ([]byte, error) {
+	g.reset()
+	g.imports[runtimePath] = "httprequest"
+	g.use("fmt")
+	g.use("net/http")
+	g.use("reflect")
+	g.use("testing")
+
+	for _, name := range names {
+		named, err := g.lookupStruct(name)
+		if err != nil {
+			return nil, err
+		}
+
+		fields, err := g.fields(named)
+		if err != nil {
+			return nil, err
+		}
+		g.emitVerify(name, fields)
+	}
+	return g.source()
+}
+
+func (g *generator) emitVerify(name string, fields []bindField) {
+	g.printf("\nfunc TestBind%sMatchesAs(t *testing.T) {\n", name)
+	g.printf("for _, mode := range []string{\"empty\", \"valid\", \"invalid\"} {\n")
+	g.printf("t.Run(mode, func(t *testing.T) {\n")
+	g.printf("var generated, reflective %s\n\n", name)
+	g.printf("genErr := Bind%s(newBind%sRequest(t, mode), &generated)\n", name, name)
+	g.printf("asErr := httprequest.As(newBind%sRequest(t, mode), &reflective)\n", name)
+	g.printf("if fmt.Sprint(genErr) != fmt.Sprint(asErr) {\n")
+	g.printf("t.Fatalf(\"errors differ:\\ngenerated:      %%v\\nhttprequest.As: %%v\", genErr, asErr)\n}\n")
+
+	for _, f := range fields {
+		if f.tag.Kind != fileTag || !types.Identical(f.typ, g.readCloserType) {
+			continue
+		}
+
+		g.use("bytes")
+		g.use("io")
+		gen := "generated" + strings.TrimPrefix(f.expr, "obj")
+		ref := "reflective" + strings.TrimPrefix(f.expr, "obj")
+		var guards []string
+		for _, a := range f.allocs {
+			guards = append(guards, "generated"+strings.TrimPrefix(a.expr, "obj")+" != nil")
+			guards = append(guards, "reflective"+strings.TrimPrefix(a.expr, "obj")+" != nil")
+		}
+		guards = append(guards, gen+" != nil", ref+" != nil")
+		g.printf("if %s {\n", strings.Join(guards, " && "))
+		g.printf("a, _ := io.ReadAll(%s)\nb, _ := io.ReadAll(%s)\n", gen, ref)
+		g.printf("%s.Close()\n%s.Close()\n", gen, ref)
+		g.printf("if !bytes.Equal(a, b) {\nt.Errorf(\"%s contents differ: %%q != %%q\", a, b)\n}\n", f.name)
+		g.printf("%s, %s = nil, nil\n}\n", gen, ref)
+	}
+
+	g.printf("if !reflect.DeepEqual(generated, reflective) {\n")
+	g.printf("t.Errorf(\"values differ:\\ngenerated:      %%+v\\nhttprequest.As: %%+v\", generated, reflective)\n}\n")
+	g.printf("})\n}\n}\n")
+
+	g.emitRequest(name, fields)
+}
+
+func (g *generator) emitRequest(name string, fields []bindField) {
+	var hasFiles, hasBody, hasForm bool
+	for _, f := range fields {
+		switch f.tag.Kind {
+		case fileTag:
+			hasFiles = true
+		case requestBodyTag:
+			hasBody = true
+		case formTag:
+			hasForm = true
+		}
+	}
+
+	g.use("net/url")
+	g.use("io")
+	g.printf("\nfunc newBind%sRequest(t *testing.T, mode string) *http.Request {\n", name)
+	g.printf("t.Helper()\n\n")
+	g.printf("var (\nbody io.Reader\ncontentType string\n\n")
+	g.printf("query = url.Values{}\nform = url.Values{}\nfiles = map[string][]string{}\n)\n\n")
+	g.printf("pick := func(valid, invalid string) string {\nif mode == \"invalid\" {\nreturn invalid\n}\nreturn valid\n}\n")
+	g.printf("_, _, _ = form, files, pick\n\n")
+
+	g.printf("if mode != \"empty\" {\n")
+	for _, f := range fields {
+		values := g.samples(f.typ, f.tag)
+		switch f.tag.Kind {
+		case urlQueryTag:
+			for _, v := range values {
+				g.printf("query.Add(%q, %s)\n", f.tag.Source, v)
+			}
+		case formTag:
+			for _, v := range values {
+				g.printf("form.Add(%q, %s)\n", f.tag.Source, v)
+			}
+		case fileTag:
+			g.printf("files[%q] = append(files[%q], \"content\")\n", f.tag.Source, f.tag.Source)
+		}
+	}
+	g.printf("}\n\n")
+
+	switch {
+	case hasFiles:
+		g.use("bytes")
+		g.use("mime/multipart")
+		g.printf("var buf bytes.Buffer\n\nw := multipart.NewWriter(&buf)\n")
+		g.printf("for key, values := range form {\nfor _, v := range values {\n")
+		g.printf("if err := w.WriteField(key, v); err != nil {\nt.Fatal(err)\n}\n}\n}\n")
+		g.printf("for key, contents := range files {\nfor _, content := range contents {\n")
+		g.printf("part, err := w.CreateFormFile(key, key+\".txt\")\nif err != nil {\nt.Fatal(err)\n}\n")
+		g.printf("if _, err := io.WriteString(part, content); err != nil {\nt.Fatal(err)\n}\n}\n}\n")
+		g.printf("if err := w.Close(); err != nil {\nt.Fatal(err)\n}\n")
+		g.printf("body, contentType = &buf, w.FormDataContentType()\n")
+	case hasBody:
+		g.use("strings")
+		g.printf("body, contentType = strings.NewReader(\"{}\"), \"application/json\"\n")
+	case hasForm:
+		g.use("strings")
+		g.printf("body, contentType = strings.NewReader(form.Encode()), \"application/x-www-form-urlencoded\"\n")
+	}
+
+	g.printf("\nreq, err := http.NewRequest(\"POST\", \"/?\"+query.Encode(), body)\n")
+	g.printf("if err != nil {\nt.Fatal(err)\n}\n")
+	g.printf("if contentType != \"\" {\nreq.Header.Set(\"Content-Type\", contentType)\n}\n\n")
+
+	g.printf("if mode != \"empty\" {\n")
+	for _, f := range fields {
+		values := g.samples(f.typ, f.tag)
+		if len(values) < 1 {
+			continue
+		}
+
+		switch f.tag.Kind {
+		case urlParamTag:
+			g.printf("req.SetPathValue(%q, %s)\n", f.tag.Source, values[0])
+		case headerTag:
+			for _, v := range values {
+				g.printf("req.Header.Add(%q, %s)\n", f.tag.Source, v)
+			}
+		case cookieTag:
+			g.printf("req.AddCookie(&http.Cookie{Name: %q, Value: %s})\n", f.tag.Source, values[0])
+		}
+	}
+	g.printf("}\nreturn req\n}\n")
+}
+
+// samples returns Go expressions for the raw values sent for a field of
+// type t. Values only need to be plausible: the test checks that both
+// binders agree, not that binding succeeds.
+func (g *generator) samples(t types.Type, tag httprequest.Tag) []string {
+	if tag.Kind == requestBodyTag || tag.Kind == fileTag {
+		return nil
+	}
+
+	if types.Identical(t, g.cookieType) || types.Identical(t, types.NewPointer(g.cookieType)) {
+		return []string{`"value"`}
+	}
+
+	var values []string
+	switch u := t.Underlying().(type) {
+	case *types.Pointer:
+		return g.samples(u.Elem(), tag)
+	case *types.Slice:
+		if g.isTextUnmarshaler(t) {
+			return []string{g.sample(t, tag)}
+		}
+		values = []string{g.sample(u.Elem(), tag), g.sample(u.Elem(), tag)}
+	case *types.Array:
+		if g.isTextUnmarshaler(t) {
+			return []string{g.sample(t, tag)}
+		}
+		for i := int64(0); i < u.Len(); i++ {
+			values = append(values, g.sample(u.Elem(), tag))
+		}
+	default:
+		return []string{g.sample(t, tag)}
+	}
+
+	if sep := tag.Separator(); sep != "" {
+		return []string{strings.Join(values, fmt.Sprintf(" + %q + ", sep))}
+	}
+	return values
+}
+
+func (g *generator) sample(t types.Type, tag httprequest.Tag) string {
+	if g.isTextUnmarshaler(t) {
+		return `"value"`
+	}
+
+	if g.isTime(t) {
+		g.use("time")
+		return fmt.Sprintf("pick(time.Date(2024, time.January, 2, 3, 4, 5, 0, time.UTC).Format(%q), \"invalid\")", tag.TimeLayout())
+	}
+
+	basic, ok := t.Underlying().(*types.Basic)
+	if !ok {
+		return `"value"`
+	}
+
+	switch info := basic.Info(); {
+	case info&types.IsBoolean != 0:
+		return `pick("true", "invalid")`
+	case info&types.IsInteger != 0:
+		return `pick("7", "invalid")`
+	case info&types.IsFloat != 0:
+		return `pick("1.5", "invalid")`
+	default:
+		return `"value"`
+	}
+}
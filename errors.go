@@ -0,0 +1,130 @@
+package httprequest
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+type (
+	FieldError struct {
+		Field  string
+		Kind   string
+		Source string
+		Value  string
+		Err    error
+
+		// Tried lists, in order, the sources looked up for a field with
+		// fallbacks.
+		Tried []TagSource
+	}
+
+	BindingErrors []*FieldError
+
+	JSONError struct {
+		Offset int64
+		Path   string
+		Err    error
+	}
+)
+
+var (
+	ErrInvalidParamTag         = errors.New("invalid param tag")
+	ErrInvalidParamTagKeyValue = errors.New("invalid param tag key-value pair")
+	ErrInvalidURLValueTag      = errors.New("invalid url value tag")
+	ErrNotMultipart            = errors.New("request is not multipart/form-data")
+	ErrFileTooLarge            = errors.New("multipart file too large")
+	ErrUploadTooLarge          = errors.New("multipart upload too large")
+	ErrInvalidFileField        = errors.New("invalid file field type")
+	ErrUnknownSourceKind       = errors.New("unknown source kind")
+	ErrDuplicateBody           = errors.New("request body bound more than once")
+	ErrInvalidTarget           = errors.New("target must be a non-nil pointer to a struct")
+	ErrArrayLength             = errors.New("wrong number of values for array")
+	ErrConverterType           = errors.New("converter returned a value of the wrong type")
+	ErrUnexportedField         = errors.New("cannot bind unexported field")
+	ErrInvalidGroup            = errors.New("group field must be a struct or a pointer to a struct")
+	ErrRecursiveGroup          = errors.New("group field contains itself")
+	ErrUnsupportedMediaType    = errors.New("unsupported media type")
+	ErrTrailingData            = errors.New("unexpected data after top-level value")
+	ErrBodyTooLarge            = errors.New("request body too large")
+	ErrInvalidSize             = errors.New("invalid size")
+	ErrUnsupportedEncoding     = errors.New("unsupported content encoding")
+	ErrInvalidRule             = errors.New("invalid validation rule")
+	ErrRequired                = errors.New("value is required")
+	ErrMin                     = errors.New("value is less than the minimum")
+	ErrMax                     = errors.New("value is greater than the maximum")
+	ErrMinLen                  = errors.New("value is shorter than the minimum length")
+	ErrMaxLen                  = errors.New("value is longer than the maximum length")
+	ErrPattern                 = errors.New("value does not match the pattern")
+	ErrOneOf                   = errors.New("value is not one of the allowed values")
+	ErrInvalidDefault          = errors.New("invalid default value")
+	ErrInvalidFallback         = errors.New("invalid fallback source")
+)
+
+func (e *FieldError) Error() string {
+	// Errors from validating the whole target name no field.
+	if e.Field == "" && e.Kind == "" {
+		return e.Err.Error()
+	}
+
+	var b strings.Builder
+
+	b.WriteString("field ")
+	b.WriteString(e.Field)
+	switch {
+	case e.Kind != "":
+		b.WriteString(" (")
+		b.WriteString(e.Kind)
+		if e.Source != "" {
+			b.WriteString("=")
+			b.WriteString(e.Source)
+		}
+		b.WriteString(")")
+	case len(e.Tried) > 0:
+		b.WriteString(" (")
+		for i, s := range e.Tried {
+			if i > 0 {
+				b.WriteString("|")
+			}
+			b.WriteString(s.String())
+		}
+		b.WriteString(")")
+	}
+	if e.Value != "" {
+		fmt.Fprintf(&b, ": invalid value %q", e.Value)
+	}
+	b.WriteString(": ")
+	b.WriteString(e.Err.Error())
+	return b.String()
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+func (e BindingErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+func (e BindingErrors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i, err := range e {
+		errs[i] = err
+	}
+	return errs
+}
+
+func (e *JSONError) Error() string {
+	if e.Path != "" {
+		return fmt.Sprintf("json: offset %d, path %s: %v", e.Offset, e.Path, e.Err)
+	}
+	return fmt.Sprintf("json: offset %d: %v", e.Offset, e.Err)
+}
+
+func (e *JSONError) Unwrap() error {
+	return e.Err
+}
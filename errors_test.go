@@ -0,0 +1,84 @@
+package httprequest
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFieldError(t *testing.T) {
+	t.Run("should format every part", func(t *testing.T) {
+		err := &FieldError{
+			Field:  "Limit",
+			Kind:   urlQueryTag,
+			Source: "limit",
+			Value:  "abc",
+			Err:    strconv.ErrSyntax,
+		}
+
+		assert.Equal(t, `field Limit (url-query=limit): invalid value "abc": invalid syntax`, err.Error())
+		assert.True(t, errors.Is(err, strconv.ErrSyntax))
+	})
+
+	t.Run("should format without source and value", func(t *testing.T) {
+		err := &FieldError{
+			Field: "Body",
+			Kind:  requestBodyTag,
+			Err:   ErrInvalidParamTag,
+		}
+
+		assert.Equal(t, "field Body (request-body): invalid param tag", err.Error())
+		assert.True(t, errors.Is(err, ErrInvalidParamTag))
+	})
+
+	t.Run("should format errors of the whole target", func(t *testing.T) {
+		err := &FieldError{Err: ErrRequired}
+
+		assert.Equal(t, "value is required", err.Error())
+	})
+
+	t.Run("should format the sources tried", func(t *testing.T) {
+		err := &FieldError{
+			Field: "Tenant",
+			Err:   ErrRequired,
+			Tried: []TagSource{{Kind: urlParamTag, Source: "tenant"}, {Kind: headerTag, Source: "X-Tenant"}},
+		}
+
+		assert.Equal(t, "field Tenant (url-param=tenant|header=X-Tenant): value is required", err.Error())
+	})
+}
+
+func TestBindingErrors(t *testing.T) {
+	errs := BindingErrors{
+		{Field: "Page", Kind: urlQueryTag, Source: "page", Value: "x", Err: strconv.ErrSyntax},
+		{Field: "Sort", Err: ErrInvalidParamTag},
+	}
+
+	var err error = errs
+	var fieldErr *FieldError
+
+	assert.Equal(t, `field Page (url-query=page): invalid value "x": invalid syntax; field Sort: invalid param tag`, err.Error())
+	assert.True(t, errors.Is(err, strconv.ErrSyntax))
+	assert.True(t, errors.Is(err, ErrInvalidParamTag))
+	assert.False(t, errors.Is(err, ErrInvalidParamTagKeyValue))
+	assert.True(t, errors.As(err, &fieldErr))
+	assert.Equal(t, "Page", fieldErr.Field)
+}
+
+func TestJSONError(t *testing.T) {
+	t.Run("should format with a path", func(t *testing.T) {
+		err := &JSONError{Offset: 12, Path: "items.0.id", Err: strconv.ErrSyntax}
+
+		assert.Equal(t, "json: offset 12, path items.0.id: invalid syntax", err.Error())
+		assert.True(t, errors.Is(err, strconv.ErrSyntax))
+	})
+
+	t.Run("should format without a path", func(t *testing.T) {
+		err := &JSONError{Offset: 3, Err: ErrTrailingData}
+
+		assert.Equal(t, "json: offset 3: unexpected data after top-level value", err.Error())
+		assert.True(t, errors.Is(err, ErrTrailingData))
+	})
+}
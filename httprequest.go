@@ -1,8 +1,17 @@
 package httprequest
 
 import (
+	"compress/gzip"
+	"compress/zlib"
+	"encoding"
 	"encoding/json"
+	"encoding/xml"
 	"errors"
+	"fmt"
+	"io"
+	"math"
+	"mime"
+	"mime/multipart"
 	"net/http"
 	"net/url"
 	"reflect"
@@ -16,104 +25,690 @@ type (
 		Param     func(*http.Request, string) string
 		Unmarshal func(*http.Request, any) error
 		Query     func(*http.Request) url.Values
+		Header    func(*http.Request) http.Header
+		Cookie    func(*http.Request, string) (*http.Cookie, error)
+		Form      func(*http.Request) (url.Values, error)
+
+		MaxMemory     int64
+		MaxFileSize   int64
+		MaxUploadSize int64
+		MaxBodyBytes  int64
+		FailFast      bool
+		Validate      ValidateFunc
+
+		Converters map[reflect.Type]Converter
+		Decoders   map[string]Decoder
+
+		Decompressors map[string]Decompressor
+	}
+
+	// Converter converts a param to a value of the type it is registered
+	// for. The map holds the tag meta of the field; it is shared by every
+	// request and must not be modified.
+	Converter func(string, map[string]string) (any, error)
+
+	Decoder func(*http.Request, any) error
+
+	binding struct {
+		binder     *Binder
+		req        *http.Request
+		cfg        *config
+		values     url.Values
+		headers    http.Header
+		form       url.Values
+		formErr    error
+		parsedForm bool
+
+		// formReq is the request forms are parsed from, once the body has
+		// been decompressed.
+		formReq    *http.Request
+		formReqErr error
+
+		multipart    *multipart.Form
+		multipartErr error
+		parsedParts  bool
+
+		// formBody is set while a form body is bound, whose fields read the
+		// same form and so cannot hold a request body of their own.
+		formBody bool
 	}
 
 	Option func(*config)
+
+	Decompressor func(io.Reader) (io.ReadCloser, error)
+
+	limitedBody struct {
+		r io.ReadCloser
+		n int64
+	}
+
+	decompressedBody struct {
+		io.ReadCloser
+		src io.Closer
+	}
 )
 
 const (
 	tagName        = "from"
 	timeLayoutMeta = "layout"
+	splitMeta      = "split"
+	prefixMeta     = "prefix"
+	maxMeta        = "max"
+	defaultMeta    = "default"
+
+	defaultMaxMemory = 32 << 20
+	defaultMediaType = "application/json"
+
+	formMediaType      = "application/x-www-form-urlencoded"
+	multipartMediaType = "multipart/form-data"
 )
 
 const (
 	urlParamTag    = "url-param"
 	urlQueryTag    = "url-query"
 	requestBodyTag = "request-body"
+	headerTag      = "header"
+	cookieTag      = "cookie"
+	formTag        = "form"
+	fileTag        = "file"
+	groupTag       = "group"
 )
 
 var (
-	ErrInvalidParamTag         = errors.New("invalid param tag")
-	ErrInvalidParamTagKeyValue = errors.New("invalid param tag key-value pair")
-	ErrInvalidURLValueTag      = errors.New("invalid url value tag")
-)
+	timeType        = reflect.TypeOf(time.Time{})
+	cookieType      = reflect.TypeOf(http.Cookie{})
+	fileHeaderType  = reflect.TypeOf(&multipart.FileHeader{})
+	fileHeadersType = reflect.TypeOf([]*multipart.FileHeader{})
+	readCloserType  = reflect.TypeOf((*io.ReadCloser)(nil)).Elem()
+	bytesType       = reflect.TypeOf([]byte{})
 
-var timeType = reflect.TypeOf(time.Time{})
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
 
 var defaultCfg = config{
-	Unmarshal: func(r *http.Request, v any) error {
-		return json.NewDecoder(r.Body).Decode(v)
-	},
 	Param: func(r *http.Request, key string) string {
 		return r.PathValue(key)
 	},
 	Query: func(r *http.Request) url.Values {
 		return r.URL.Query()
 	},
+	Header: func(r *http.Request) http.Header {
+		return r.Header
+	},
+	Cookie: func(r *http.Request, name string) (*http.Cookie, error) {
+		return r.Cookie(name)
+	},
+	Form: func(r *http.Request) (url.Values, error) {
+		if err := r.ParseForm(); err != nil {
+			return nil, err
+		}
+		return r.PostForm, nil
+	},
+	Decoders: map[string]Decoder{
+		"application/json": decodeJSON,
+		"application/xml":  decodeXML,
+		"text/xml":         decodeXML,
+	},
+	Decompressors: map[string]Decompressor{
+		"gzip":    gunzip,
+		"x-gzip":  gunzip,
+		"deflate": inflate,
+	},
+	MaxMemory: defaultMaxMemory,
 }
 
 func As(req *http.Request, obj any, opts ...Option) error {
-	var (
-		values url.Values
+	return defaultBinder.As(req, obj, opts...)
+}
+
+// Prepare is Binder.Prepare for the Binder used by As and Bind.
+func Prepare(objs ...any) error {
+	return defaultBinder.Prepare(objs...)
+}
+
+// Bind allocates a T and binds req into it like As. Go generics cannot
+// restrict T to struct types, so any other T fails with ErrInvalidTarget.
+func Bind[T any](req *http.Request, opts ...Option) (T, error) {
+	return BindWith[T](defaultBinder, req, opts...)
+}
+
+func MustBind[T any](req *http.Request, opts ...Option) T {
+	obj, err := Bind[T](req, opts...)
+	if err != nil {
+		panic(err)
+	}
+	return obj
+}
 
-		cfg         = defaultCfg
-		decodedBody = false
+// bind binds every field of the struct v, then validates it.
+func (b *binding) bind(v reflect.Value) error {
+	errs := b.bindFields(v)
+	if errs = b.validateTarget(errs, v.Addr().Interface()); len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// bindFields binds every field of the struct v, validating its request body.
+func (b *binding) bindFields(v reflect.Value) BindingErrors {
+	p := b.binder.cachedPlan(v.Type())
+	if len(p.errs) > 0 {
+		return p.errs
+	}
+
+	var (
+		errs BindingErrors
+		body *field
 	)
+	for i, f := range p.fields {
+		if err := b.bindField(v, f); err != nil {
+			errs = append(errs, err)
+			if b.cfg.FailFast {
+				break
+			}
+		} else if f.kind == requestBodyTag {
+			body = &p.fields[i]
+		}
+	}
 
-	for _, opt := range opts {
-		opt(&cfg)
+	if body != nil {
+		target := fieldByIndex(v, body.index)
+		if target.Kind() != reflect.Pointer {
+			target = target.Addr()
+		}
+		errs = b.validateBody(errs, body.name, target.Interface())
 	}
+	return errs
+}
 
-	values = cfg.Query(req)
-	v := reflect.ValueOf(obj).Elem()
-	for i, f := range reflect.VisibleFields(v.Type()) {
-		tag := f.Tag.Get(tagName)
-		if tag == "" || tag == "-" {
-			continue
+func (b *binding) bindField(v reflect.Value, f field) *FieldError {
+	fail := func(value string, err error) *FieldError {
+		return &FieldError{
+			Field:  f.name,
+			Kind:   f.kind,
+			Source: f.source,
+			Value:  value,
+			Err:    err,
 		}
+	}
 
-		kind, source, meta, err := splitTag(tag)
+	pointer := f.typ.Kind() == reflect.Pointer
+	if f.chain != nil {
+		return b.bindChain(v, f, pointer)
+	}
+
+	switch f.kind {
+	case cookieTag:
+		c, err := b.cfg.Cookie(b.req, f.source)
+		if errors.Is(err, http.ErrNoCookie) && f.defaults != nil {
+			c, err = &http.Cookie{Name: f.source, Value: f.defaults[0]}, nil
+		}
+		if errors.Is(err, http.ErrNoCookie) && pointer {
+			if f.rules.required() {
+				return fail("", ErrRequired)
+			}
+			return nil
+		}
 		if err != nil {
-			return err
+			return fail("", err)
 		}
 
-		switch kind {
-		case urlParamTag:
-			setValue(v.FieldByName(f.Name), cfg.Param(req, source), meta)
-		case urlQueryTag:
-			setValue(v.FieldByName(f.Name), values.Get(source), meta)
-		case requestBodyTag:
-			if decodedBody {
-				panic("Cannot decode the body twice")
+		target := fieldByIndex(v, f.index)
+		if err := setCookie(target, c, b.conversion(f)); err != nil {
+			return fail(c.Value, err)
+		}
+		if err := f.rules.check(target); err != nil {
+			return fail(c.Value, err)
+		}
+	case fileTag:
+		headers, err := b.files(f.source)
+		if err != nil {
+			return fail("", err)
+		}
+		if err := setFile(fieldByIndex(v, f.index), headers); err != nil {
+			return fail("", err)
+		}
+	case requestBodyTag:
+		target := fieldByIndex(v, f.index)
+		if pointer {
+			if target.IsNil() {
+				typ := target.Type().Elem()
+				target.Set(reflect.New(typ))
 			}
+		} else {
+			target = target.Addr()
+		}
 
-			decodedBody = true
-			rvalue := reflect.ValueOf(req)
-			target := v.FieldByIndex([]int{i})
-			decode := reflect.ValueOf(cfg.Unmarshal)
-			if target.Kind() == reflect.Pointer {
-				if target.IsNil() {
-					typ := target.Type().Elem()
-					target.Set(reflect.New(typ))
-				}
+		if err := b.decode(target.Interface(), f.maxBytes); err != nil {
+			return fail("", err)
+		}
+	default:
+		params, err := b.lookup(f.kind, f.source)
+		if err != nil {
+			return fail("", err)
+		}
+		if !isPresent(params, pointer) {
+			switch {
+			case f.defaults != nil:
+				params = f.defaults
+			case f.rules.required():
+				return fail("", ErrRequired)
+			default:
+				return nil
 			}
+		}
 
-			in := []reflect.Value{rvalue, target}
-			if target.Kind() != reflect.Pointer {
-				in[1] = target.Addr()
-			}
+		target := fieldByIndex(v, f.index)
+		if err := setValues(target, params, b.conversion(f)); err != nil {
+			return fail(strings.Join(params, ","), err)
+		}
+		if err := f.rules.check(target); err != nil {
+			return fail(strings.Join(params, ","), err)
+		}
+	}
+	return nil
+}
+
+// conversion returns how params are set into f. Plans resolve it with the
+// converters of the Binder, so it is resolved again when a call adds some.
+func (b *binding) conversion(f field) *conversion {
+	if reflect.ValueOf(b.cfg.Converters).UnsafePointer() == reflect.ValueOf(b.binder.cfg.Converters).UnsafePointer() {
+		return f.conversion
+	}
+	return newConversion(f.typ, f.conversion.meta, b.cfg.Converters)
+}
+
+// bindChain binds a field with fallbacks from the first of its sources that
+// has a value.
+func (b *binding) bindChain(v reflect.Value, f field, pointer bool) *FieldError {
+	src, params, tried, err := b.lookupFirst(f.chain, pointer)
+	fail := func(value string, err error) *FieldError {
+		return &FieldError{
+			Field:  f.name,
+			Kind:   src.Kind,
+			Source: src.Source,
+			Value:  value,
+			Err:    err,
+			Tried:  tried,
+		}
+	}
+
+	if err != nil {
+		return fail("", err)
+	}
+	if len(params) < 1 {
+		switch {
+		case f.defaults != nil:
+			params = f.defaults
+		case f.rules.required():
+			return fail("", ErrRequired)
+		default:
+			return nil
+		}
+	}
+
+	target := fieldByIndex(v, f.index)
+	if err := setValues(target, params, b.conversion(f)); err != nil {
+		return fail(strings.Join(params, ","), err)
+	}
+	if err := f.rules.check(target); err != nil {
+		return fail(strings.Join(params, ","), err)
+	}
+	return nil
+}
+
+// lookupFirst returns the values of the first source in chain holding one,
+// that source and the sources looked up so far. The source is zero when none
+// has a value.
+func (b *binding) lookupFirst(chain []TagSource, pointer bool) (TagSource, []string, []TagSource, error) {
+	for i, s := range chain {
+		params, err := b.lookup(s.Kind, s.Source)
+		if err != nil || isPresent(params, pointer) {
+			return s, params, chain[:i+1], err
+		}
+	}
+	return TagSource{}, nil, chain, nil
+}
+
+func (b *binding) lookup(kind, source string) ([]string, error) {
+	switch kind {
+	case cookieTag:
+		c, err := b.cfg.Cookie(b.req, source)
+		if errors.Is(err, http.ErrNoCookie) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		return []string{c.Value}, nil
+	case urlParamTag:
+		if p := b.cfg.Param(b.req, source); p != "" {
+			return []string{p}, nil
+		}
+		return nil, nil
+	case urlQueryTag:
+		if b.values == nil {
+			b.values = b.cfg.Query(b.req)
+		}
+		return b.values[source], nil
+	case headerTag:
+		return b.headers.Values(source), nil
+	case formTag:
+		if !b.parsedForm {
+			b.parsedForm = true
+			b.form, b.formErr = b.parseForm()
+		}
+		return b.form[source], b.formErr
+	default:
+		return nil, ErrUnknownSourceKind
+	}
+}
+
+func (b *binding) parseForm() (url.Values, error) {
+	req, err := b.formRequest()
+	if err != nil {
+		return nil, err
+	}
+	if isMultipart(req) {
+		if _, err := b.parseMultipart(); err != nil {
+			return nil, err
+		}
+	}
+	return b.cfg.Form(req)
+}
+
+// formRequest returns the request forms are parsed from. When the body is
+// encoded, it is a copy of req reading the decoded body. It is not limited
+// to MaxBodyBytes: ParseForm and the multipart limits bound form bodies.
+func (b *binding) formRequest() (*http.Request, error) {
+	if b.formReq == nil && b.formReqErr == nil {
+		if b.req.PostForm != nil || b.req.MultipartForm != nil {
+			// Already parsed, likely by a middleware.
+			b.formReq = b.req
+		} else {
+			b.formReq, b.formReqErr = b.bodyRequest(0)
+		}
+	}
+	return b.formReq, b.formReqErr
+}
+
+// parseMultipart parses the multipart body once; every call reports the
+// same error, so no field can bypass the size limits.
+func (b *binding) parseMultipart() (*multipart.Form, error) {
+	if !b.parsedParts {
+		b.parsedParts = true
+		if req, err := b.formRequest(); err != nil {
+			b.multipartErr = err
+		} else {
+			b.multipart, b.multipartErr = parseMultipart(req, b.cfg)
+		}
+	}
+	return b.multipart, b.multipartErr
+}
+
+func (b *binding) files(source string) ([]*multipart.FileHeader, error) {
+	mf, err := b.parseMultipart()
+	if err != nil {
+		return nil, err
+	}
 
-			ret := decode.Call(in)
-			if len(ret) > 0 && !ret[0].IsNil() {
-				return ret[0].Interface().(error)
+	headers := mf.File[source]
+	if len(headers) < 1 {
+		return nil, http.ErrMissingFile
+	}
+	return headers, nil
+}
+
+// decode reads the request body into v with the Unmarshal function, if one
+// was set, or else with the decoder registered for the body's media type.
+// Form bodies are bound like the enclosing struct unless a decoder was
+// registered for them, and requests without a Content-Type are decoded as
+// JSON. Decoders see the body with its Content-Encoding undone, and read at
+// most maxBytes of it, or MaxBodyBytes when maxBytes is zero.
+func (b *binding) decode(v any, maxBytes int64) error {
+	if maxBytes == 0 {
+		maxBytes = b.cfg.MaxBodyBytes
+	}
+
+	if b.cfg.Unmarshal != nil {
+		req, err := b.bodyRequest(maxBytes)
+		if err != nil {
+			return err
+		}
+		return b.cfg.Unmarshal(req, v)
+	}
+
+	mediaType := defaultMediaType
+	if ct := b.req.Header.Get("Content-Type"); ct != "" {
+		mt, _, err := mime.ParseMediaType(ct)
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrUnsupportedMediaType, err)
+		}
+		mediaType = mt
+	}
+
+	if dec, ok := lookupDecoder(b.cfg.Decoders, mediaType); ok {
+		req, err := b.bodyRequest(maxBytes)
+		if err != nil {
+			return err
+		}
+		return dec(req, v)
+	}
+	if mediaType == formMediaType || mediaType == multipartMediaType {
+		if _, err := b.formRequest(); err != nil {
+			return err
+		}
+		return b.decodeForm(v)
+	}
+	return fmt.Errorf("%w: %s", ErrUnsupportedMediaType, mediaType)
+}
+
+// bodyRequest returns req, or a copy of it whose body is decompressed and
+// limited to maxBytes when needed.
+func (b *binding) bodyRequest(maxBytes int64) (*http.Request, error) {
+	// Client requests may have a nil Body, which servers never pass.
+	body := b.req.Body
+	if body == nil {
+		body = http.NoBody
+	}
+
+	encodings := b.req.Header.Values("Content-Encoding")
+	body, err := decompress(body, encodings, b.cfg.Decompressors)
+	if err != nil {
+		return nil, err
+	}
+	if maxBytes > 0 {
+		body = &limitedBody{r: body, n: maxBytes}
+	}
+
+	req := b.req
+	if body != req.Body {
+		req = new(http.Request)
+		*req = *b.req
+		req.Body = body
+		if len(encodings) > 0 {
+			req.Header = b.req.Header.Clone()
+			req.Header.Del("Content-Encoding")
+		}
+	}
+	return req, nil
+}
+
+// decompress undoes the content codings of body, listed in encodings in the
+// order they were applied.
+func decompress(body io.ReadCloser, encodings []string, decs map[string]Decompressor) (io.ReadCloser, error) {
+	var codings []string
+	for _, v := range encodings {
+		for _, c := range strings.Split(v, ",") {
+			if c = strings.ToLower(strings.TrimSpace(c)); c != "" && c != "identity" {
+				codings = append(codings, c)
 			}
+		}
+	}
+
+	for i := len(codings) - 1; i >= 0; i-- {
+		dec, ok := decs[codings[i]]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrUnsupportedEncoding, codings[i])
+		}
+
+		r, err := dec(body)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", codings[i], err)
+		}
+		body = &decompressedBody{ReadCloser: r, src: body}
+	}
+	return body, nil
+}
+
+func (d *decompressedBody) Close() error {
+	err := d.ReadCloser.Close()
+	if srcErr := d.src.Close(); err == nil {
+		err = srcErr
+	}
+	return err
+}
+
+func gunzip(r io.Reader) (io.ReadCloser, error) {
+	return gzip.NewReader(r)
+}
+
+// inflate reads the zlib format that HTTP calls deflate.
+func inflate(r io.Reader) (io.ReadCloser, error) {
+	return zlib.NewReader(r)
+}
+
+// Read fails with ErrBodyTooLarge once more than n bytes were read.
+func (l *limitedBody) Read(p []byte) (int, error) {
+	if l.n < 0 {
+		return 0, ErrBodyTooLarge
+	}
+	if int64(len(p)) > l.n+1 {
+		p = p[:l.n+1]
+	}
+
+	n, err := l.r.Read(p)
+	if int64(n) > l.n {
+		n, l.n = int(l.n), -1
+		return n, ErrBodyTooLarge
+	}
+	l.n -= int64(n)
+	return n, err
+}
+
+func (l *limitedBody) Close() error {
+	return l.r.Close()
+}
+
+// lookupDecoder finds the decoder for mediaType, falling back on the one for
+// its structured syntax suffix, so that application/atom+xml is decoded as
+// application/xml.
+func lookupDecoder(decs map[string]Decoder, mediaType string) (Decoder, bool) {
+	if dec, ok := decs[mediaType]; ok {
+		return dec, true
+	}
+	if i := strings.LastIndexByte(mediaType, '+'); i >= 0 {
+		dec, ok := decs["application/"+mediaType[i+1:]]
+		return dec, ok
+	}
+	return nil, false
+}
+
+func decodeJSON(r *http.Request, v any) error {
+	return json.NewDecoder(r.Body).Decode(v)
+}
+
+// strictJSON decodes a single JSON value, rejecting unknown fields and any
+// data after it.
+func strictJSON(useNumber bool) Decoder {
+	return func(r *http.Request, v any) error {
+		dec := json.NewDecoder(r.Body)
+		dec.DisallowUnknownFields()
+		if useNumber {
+			dec.UseNumber()
+		}
+
+		if err := dec.Decode(v); err != nil {
+			return newJSONError(dec, err)
+		}
+		var syntaxErr *json.SyntaxError
+		switch _, err := dec.Token(); {
+		case err == io.EOF:
+			return nil
+		case err == nil || errors.As(err, &syntaxErr):
+			return &JSONError{Offset: dec.InputOffset(), Err: ErrTrailingData}
 		default:
-			panic("Invalid kind: " + kind)
+			// Read errors, such as ErrBodyTooLarge, are not about the data.
+			return &JSONError{Offset: dec.InputOffset(), Err: err}
 		}
 	}
+}
+
+func newJSONError(dec *json.Decoder, err error) *JSONError {
+	var (
+		syntaxErr *json.SyntaxError
+		typeErr   *json.UnmarshalTypeError
+
+		e = &JSONError{Offset: dec.InputOffset(), Err: err}
+	)
+
+	switch {
+	case errors.As(err, &syntaxErr):
+		e.Offset = syntaxErr.Offset
+	case errors.As(err, &typeErr):
+		e.Offset = typeErr.Offset
+		e.Path = typeErr.Field
+	}
+	return e
+}
+
+func decodeXML(r *http.Request, v any) error {
+	return xml.NewDecoder(r.Body).Decode(v)
+}
+
+// decodeForm binds the tagged fields of the body struct v, typically
+// from:"form=..." ones, with the options of the enclosing call.
+func (b *binding) decodeForm(v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("%w: %T", ErrInvalidTarget, v)
+	}
+	if b.formBody {
+		return fmt.Errorf("%w: inside a form body", ErrDuplicateBody)
+	}
+
+	b.formBody = true
+	defer func() { b.formBody = false }()
+
+	// The body is validated by the binding it belongs to.
+	if errs := b.bindFields(rv.Elem()); len(errs) > 0 {
+		return errs
+	}
 	return nil
 }
 
+// fieldByIndex is like reflect.Value.FieldByIndex but allocates the nil
+// embedded struct pointers on the way.
+func fieldByIndex(v reflect.Value, index []int) reflect.Value {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v
+}
+
+func isPresent(params []string, pointer bool) bool {
+	if len(params) < 1 {
+		return false
+	}
+	return pointer || len(params) > 1 || params[0] != ""
+}
+
 func WithURLParamFunc(getter func(*http.Request, string) string) Option {
 	return func(cfg *config) {
 		cfg.Param = getter
@@ -132,7 +727,286 @@ func WithQueryFunc(q func(*http.Request) url.Values) Option {
 	}
 }
 
-func setValue(f reflect.Value, param string, meta map[string]string) error {
+func WithHeaderFunc(h func(*http.Request) http.Header) Option {
+	return func(cfg *config) {
+		cfg.Header = h
+	}
+}
+
+func WithCookieFunc(c func(*http.Request, string) (*http.Cookie, error)) Option {
+	return func(cfg *config) {
+		cfg.Cookie = c
+	}
+}
+
+func WithFormFunc(form func(*http.Request) (url.Values, error)) Option {
+	return func(cfg *config) {
+		cfg.Form = form
+	}
+}
+
+func WithConverter(t reflect.Type, conv Converter) Option {
+	return func(cfg *config) {
+		convs := make(map[reflect.Type]Converter, len(cfg.Converters)+1)
+		for k, v := range cfg.Converters {
+			convs[k] = v
+		}
+		convs[t] = conv
+		cfg.Converters = convs
+	}
+}
+
+// WithDecoder registers dec for request bodies of the given media type,
+// replacing any decoder already registered for it.
+func WithDecoder(mediaType string, dec Decoder) Option {
+	return func(cfg *config) {
+		decs := make(map[string]Decoder, len(cfg.Decoders)+1)
+		for k, v := range cfg.Decoders {
+			decs[k] = v
+		}
+		decs[strings.ToLower(mediaType)] = dec
+		cfg.Decoders = decs
+	}
+}
+
+// WithStrictJSON decodes JSON bodies rejecting unknown fields and trailing
+// data, reporting failures as a *JSONError. With useNumber, numbers decoded
+// into interface values are json.Number rather than float64.
+func WithStrictJSON(useNumber bool) Option {
+	return WithDecoder(defaultMediaType, strictJSON(useNumber))
+}
+
+// WithMaxBodyBytes limits how much of the request body is decoded into
+// request-body fields, failing with ErrBodyTooLarge past it. A max=<size>
+// tag meta such as max=1MB overrides it per field. Form bodies are bound
+// within the form and multipart limits instead.
+func WithMaxBodyBytes(n int64) Option {
+	return func(cfg *config) {
+		cfg.MaxBodyBytes = n
+	}
+}
+
+// WithDecompressor registers dec for request bodies sent with the given
+// Content-Encoding, e.g. br or zstd.
+func WithDecompressor(encoding string, dec Decompressor) Option {
+	return func(cfg *config) {
+		decs := make(map[string]Decompressor, len(cfg.Decompressors)+1)
+		for k, v := range cfg.Decompressors {
+			decs[k] = v
+		}
+		decs[strings.ToLower(encoding)] = dec
+		cfg.Decompressors = decs
+	}
+}
+
+// WithValidator sets a validator run on the target once every field is bound,
+// after its own Validate or ValidateRequest method.
+func WithValidator(validate ValidateFunc) Option {
+	return func(cfg *config) {
+		cfg.Validate = validate
+	}
+}
+
+func WithFailFast() Option {
+	return func(cfg *config) {
+		cfg.FailFast = true
+	}
+}
+
+func WithMaxMemory(n int64) Option {
+	return func(cfg *config) {
+		cfg.MaxMemory = n
+	}
+}
+
+func WithMaxFileSize(n int64) Option {
+	return func(cfg *config) {
+		cfg.MaxFileSize = n
+	}
+}
+
+func WithMaxUploadSize(n int64) Option {
+	return func(cfg *config) {
+		cfg.MaxUploadSize = n
+	}
+}
+
+func isMultipart(req *http.Request) bool {
+	mediaType, _, err := mime.ParseMediaType(req.Header.Get("Content-Type"))
+	return err == nil && mediaType == multipartMediaType
+}
+
+// parseMultipart parses the multipart body of req, unless a middleware
+// already did, and checks the size limits. The body is limited to
+// MaxUploadSize while it is parsed.
+func parseMultipart(req *http.Request, cfg *config) (*multipart.Form, error) {
+	if req.MultipartForm == nil {
+		if !isMultipart(req) {
+			return nil, ErrNotMultipart
+		}
+
+		if cfg.MaxUploadSize > 0 {
+			req.Body = http.MaxBytesReader(nil, req.Body, cfg.MaxUploadSize)
+		}
+		if err := req.ParseMultipartForm(cfg.MaxMemory); err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				return nil, fmt.Errorf("multipart: %w", ErrUploadTooLarge)
+			}
+			return nil, fmt.Errorf("multipart: %w", err)
+		}
+	}
+
+	total := int64(0)
+	for name, headers := range req.MultipartForm.File {
+		for _, h := range headers {
+			if cfg.MaxFileSize > 0 && h.Size > cfg.MaxFileSize {
+				return nil, fmt.Errorf("multipart part %q: %w", name, ErrFileTooLarge)
+			}
+
+			total += h.Size
+			if cfg.MaxUploadSize > 0 && total > cfg.MaxUploadSize {
+				return nil, fmt.Errorf("multipart part %q: %w", name, ErrUploadTooLarge)
+			}
+		}
+	}
+	return req.MultipartForm, nil
+}
+
+func isFileType(t reflect.Type) bool {
+	switch t {
+	case fileHeaderType, fileHeadersType, readCloserType, bytesType:
+		return true
+	default:
+		return false
+	}
+}
+
+func setFile(f reflect.Value, headers []*multipart.FileHeader) error {
+	switch f.Type() {
+	case fileHeaderType:
+		f.Set(reflect.ValueOf(headers[0]))
+	case fileHeadersType:
+		f.Set(reflect.ValueOf(headers))
+	case readCloserType:
+		file, err := headers[0].Open()
+		if err != nil {
+			return err
+		}
+		f.Set(reflect.ValueOf(file))
+	case bytesType:
+		file, err := headers[0].Open()
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+
+		data, err := io.ReadAll(file)
+		if err != nil {
+			return err
+		}
+		f.SetBytes(data)
+	default:
+		return ErrInvalidFileField
+	}
+	return nil
+}
+
+func setCookie(f reflect.Value, c *http.Cookie, conv *conversion) error {
+	switch {
+	case f.Type() == cookieType:
+		f.Set(reflect.ValueOf(*c))
+	case f.Kind() == reflect.Pointer && f.Type().Elem() == cookieType:
+		f.Set(reflect.ValueOf(c))
+	default:
+		return setValues(f, []string{c.Value}, conv)
+	}
+	return nil
+}
+
+func setValues(f reflect.Value, params []string, conv *conversion) error {
+	if conv.sep != "" {
+		split := make([]string, 0, len(params))
+		for _, p := range params {
+			split = append(split, strings.Split(p, conv.sep)...)
+		}
+		params = split
+	}
+
+	if f.Type() == conv.leaf {
+		return conv.convert(f, params[0])
+	}
+
+	switch f.Kind() {
+	case reflect.Pointer:
+		p := reflect.New(f.Type().Elem())
+		if err := setValues(p.Elem(), params, conv); err != nil {
+			return err
+		}
+		f.Set(p)
+	case reflect.Slice:
+		s := reflect.MakeSlice(f.Type(), len(params), len(params))
+		if err := setElements(s, params, conv); err != nil {
+			return err
+		}
+		f.Set(s)
+	case reflect.Array:
+		if len(params) != f.Len() {
+			return fmt.Errorf("%w: got %d values, want %d", ErrArrayLength, len(params), f.Len())
+		}
+
+		a := reflect.New(f.Type()).Elem()
+		if err := setElements(a, params, conv); err != nil {
+			return err
+		}
+		f.Set(a)
+	default:
+		return conv.convert(f, params[0])
+	}
+	return nil
+}
+
+func setElements(f reflect.Value, params []string, conv *conversion) error {
+	for i, p := range params {
+		if err := conv.convert(f.Index(i), p); err != nil {
+			return fmt.Errorf("element %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// convert sets f, a value of the leaf type, from param.
+func (c *conversion) convert(f reflect.Value, param string) error {
+	if c.conv == nil {
+		return setValue(f, param, c.layout)
+	}
+	return setConverted(f, c.conv, param, c.meta)
+}
+
+// setConverted sets f to the value conv returns for param.
+func setConverted(f reflect.Value, conv Converter, param string, meta map[string]string) error {
+	v, err := conv(param, meta)
+	if err != nil {
+		return err
+	}
+
+	rv := reflect.ValueOf(v)
+	if !rv.IsValid() || !rv.Type().AssignableTo(f.Type()) {
+		return fmt.Errorf("%w: got %T, want %s", ErrConverterType, v, f.Type())
+	}
+	f.Set(rv)
+	return nil
+}
+
+func isTextUnmarshaler(t reflect.Type) bool {
+	return t != timeType && reflect.PointerTo(t).Implements(textUnmarshalerType)
+}
+
+func setValue(f reflect.Value, param string, layout string) error {
+	if isTextUnmarshaler(f.Type()) && f.CanAddr() {
+		return f.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(param))
+	}
+
 	switch f.Kind() {
 	case reflect.Bool:
 		if v, err := strconv.ParseBool(param); err != nil {
@@ -218,7 +1092,6 @@ func setValue(f reflect.Value, param string, meta map[string]string) error {
 
 	switch f.Type() {
 	case timeType:
-		layout := timeLayout(meta)
 		t, err := time.Parse(layout, param)
 		if err != nil {
 			return err
@@ -229,41 +1102,49 @@ func setValue(f reflect.Value, param string, meta map[string]string) error {
 	return nil
 }
 
-func splitTag(tag string) (kind, source string, meta map[string]string, err error) {
+func splitTag(tag string) (sources []TagSource, meta map[string]string, err error) {
 	parts := strings.Split(tag, ",")
 	if len(parts) < 1 || parts[0] == "" {
-		return "", "", nil, ErrInvalidParamTag
+		return nil, nil, ErrInvalidParamTag
 	}
 
-	kv := strings.TrimSpace(parts[0])
-	if kv == "" || kv == "=" {
-		return "", "", nil, ErrInvalidParamTag
-	}
+	// The first part lists the sources, separated by "|". Meta may contain
+	// "|" too, as in oneof=a|b.
+	for _, kv := range strings.Split(parts[0], "|") {
+		kv = strings.TrimSpace(kv)
+		if kv == "" || kv == "=" {
+			return nil, nil, ErrInvalidParamTag
+		}
 
-	if kv == requestBodyTag {
-		return kv, "", nil, nil
-	}
+		if kv == requestBodyTag || kv == groupTag {
+			sources = append(sources, TagSource{Kind: kv})
+			continue
+		}
 
-	kind, source, err = splitKV(kv)
+		kind, source, err := splitKV(kv)
+		if err != nil {
+			return nil, nil, err
+		}
+		sources = append(sources, TagSource{Kind: kind, Source: source})
+	}
 	if len(parts) < 2 {
-		return kind, source, nil, err
+		return sources, nil, nil
 	}
 
+	// Meta are key=value pairs, or the bare required key, which takes no
+	// value. Values may contain "=" but not ",".
 	m := make(map[string]string)
 	for _, p := range parts[1:] {
-		key, value, err := splitKV(p)
-		if err != nil {
-			return "", "", nil, err
-		}
+		key, value, found := strings.Cut(p, "=")
 
 		k := strings.TrimSpace(key)
 		v := strings.TrimSpace(value)
-		if k == "" || v == "" {
-			return "", "", nil, ErrInvalidParamTagKeyValue
+		if k == "" || (found && (v == "" || k == requiredMeta)) || (!found && k != requiredMeta) {
+			return nil, nil, ErrInvalidParamTagKeyValue
 		}
 		m[k] = v
 	}
-	return kind, source, m, nil
+	return sources, m, nil
 }
 
 func splitKV(kv string) (string, string, error) {
@@ -274,6 +1155,46 @@ func splitKV(kv string) (string, string, error) {
 	return parts[0], parts[1], nil
 }
 
+// parseSize parses sizes such as 512, 64KB or 1MB, counting in powers of
+// 1024.
+func parseSize(s string) (int64, error) {
+	var (
+		unit  int64 = 1
+		upper       = strings.ToUpper(s)
+	)
+
+	for _, u := range []struct {
+		suffix string
+		size   int64
+	}{{"GB", 1 << 30}, {"MB", 1 << 20}, {"KB", 1 << 10}, {"B", 1}} {
+		if strings.HasSuffix(upper, u.suffix) {
+			upper, unit = strings.TrimSuffix(upper, u.suffix), u.size
+			break
+		}
+	}
+
+	n, err := strconv.ParseInt(strings.TrimSpace(upper), 10, 64)
+	if err != nil || n <= 0 || n > math.MaxInt64/unit {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidSize, s)
+	}
+	return n * unit, nil
+}
+
+func splitSeparator(m map[string]string) string {
+	switch sep := m[splitMeta]; sep {
+	case "comma":
+		return ","
+	case "semicolon":
+		return ";"
+	case "space":
+		return " "
+	case "pipe":
+		return "|"
+	default:
+		return sep
+	}
+}
+
 func timeLayout(m map[string]string) string {
 	switch layout := m[timeLayoutMeta]; layout {
 	case "Layout":
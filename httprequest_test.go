@@ -1,9 +1,22 @@
 package httprequest
 
 import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"encoding/base64"
 	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime/multipart"
 	"net/http"
 	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -34,6 +47,20 @@ type (
 		ID   int64  `json:"id"`
 		Name string `json:"name"`
 	}
+
+	Pagination struct {
+		Limit  int `from:"url-query=limit"`
+		Offset int `from:"url-query=offset"`
+	}
+
+	Filters struct {
+		Owner string    `from:"url-query=owner"`
+		Body  *testBody `from:"request-body"`
+	}
+
+	filters struct {
+		Owner string `from:"url-query=owner"`
+	}
 )
 
 func TestOptions(t *testing.T) {
@@ -41,8 +68,29 @@ func TestOptions(t *testing.T) {
 
 	WithQueryFunc(defaultCfg.Query)(&cfg)
 	WithURLParamFunc(defaultCfg.Param)(&cfg)
-	WithUnmarshaller(defaultCfg.Unmarshal)(&cfg)
+	WithUnmarshaller(decodeJSON)(&cfg)
+	WithHeaderFunc(defaultCfg.Header)(&cfg)
+	WithCookieFunc(defaultCfg.Cookie)(&cfg)
+	WithFormFunc(defaultCfg.Form)(&cfg)
+	WithMaxMemory(1024)(&cfg)
+	WithMaxFileSize(512)(&cfg)
+	WithMaxUploadSize(2048)(&cfg)
+	WithMaxBodyBytes(4096)(&cfg)
+	WithDecompressor("ZSTD", gunzip)(&cfg)
+	WithFailFast()(&cfg)
+	WithDecoder("Application/CBOR", decodeJSON)(&cfg)
 
+	assert.True(t, cfg.FailFast)
+	assert.NotNil(t, cfg.Decoders["application/cbor"])
+	assert.NotNil(t, cfg.Decompressors["zstd"])
+
+	assert.Equal(t, int64(1024), cfg.MaxMemory)
+	assert.Equal(t, int64(512), cfg.MaxFileSize)
+	assert.Equal(t, int64(2048), cfg.MaxUploadSize)
+	assert.Equal(t, int64(4096), cfg.MaxBodyBytes)
+	assert.NotNil(t, cfg.Form)
+	assert.NotNil(t, cfg.Cookie)
+	assert.NotNil(t, cfg.Header)
 	assert.NotNil(t, cfg.Query)
 	assert.NotNil(t, cfg.Param)
 	assert.NotNil(t, cfg.Unmarshal)
@@ -165,3 +213,1989 @@ func TestAs(t *testing.T) {
 		assert.Nil(t, err)
 	})
 }
+
+func TestBind(t *testing.T) {
+	t.Run("should allocate and bind the value", func(t *testing.T) {
+		body := bytes.NewBufferString(`{"id":2,"name":"two"}`)
+		req, reqErr := http.NewRequest("POST", "/?query_a=a", body)
+		require.Nil(t, reqErr)
+
+		obj, err := Bind[testStruct](req, WithURLParamFunc(func(r *http.Request, key string) string {
+			if key == "id" {
+				return "1"
+			}
+			return "true"
+		}))
+
+		require.Nil(t, err)
+		assert.Equal(t, int64(1), obj.ID)
+		assert.True(t, obj.Flag)
+		assert.Equal(t, "a", obj.QueryA)
+		assert.Equal(t, testBody{ID: 2, Name: "two"}, obj.Body)
+	})
+
+	t.Run("should return the binding errors", func(t *testing.T) {
+		req, reqErr := http.NewRequest("POST", "/?query_t=yesterday", strings.NewReader("{}"))
+		require.Nil(t, reqErr)
+
+		_, err := Bind[testStruct](req)
+
+		var errs BindingErrors
+		require.ErrorAs(t, err, &errs)
+	})
+
+	t.Run("should reject types that are not structs", func(t *testing.T) {
+		req, reqErr := http.NewRequest("GET", "/", nil)
+		require.Nil(t, reqErr)
+
+		_, err := Bind[int](req)
+		assert.ErrorIs(t, err, ErrInvalidTarget)
+
+		_, err = Bind[*testStruct](req)
+		assert.ErrorIs(t, err, ErrInvalidTarget)
+	})
+
+	t.Run("should panic on errors with MustBind", func(t *testing.T) {
+		req, reqErr := http.NewRequest("GET", "/?query_a=a", nil)
+		require.Nil(t, reqErr)
+
+		assert.PanicsWithError(t, fmt.Sprintf("%v: *int", ErrInvalidTarget), func() {
+			MustBind[int](req)
+		})
+		assert.NotPanics(t, func() {
+			obj := MustBind[struct {
+				QueryA string `from:"url-query=query_a"`
+			}](req)
+			assert.Equal(t, "a", obj.QueryA)
+		})
+	})
+}
+
+func TestAsBodyMediaTypes(t *testing.T) {
+	type formBody struct {
+		ID   int64  `from:"form=id" xml:"id"`
+		Name string `from:"form=name" xml:"name"`
+	}
+
+	type bodyStruct struct {
+		Sort string   `from:"url-query=sort"`
+		Body formBody `from:"request-body"`
+	}
+
+	newRequest := func(t *testing.T, contentType, body string) *http.Request {
+		req, reqErr := http.NewRequest("POST", "/?sort=name", strings.NewReader(body))
+		require.Nil(t, reqErr)
+
+		if contentType != "" {
+			req.Header.Set("Content-Type", contentType)
+		}
+		return req
+	}
+
+	expected := bodyStruct{Sort: "name", Body: formBody{ID: 1, Name: "one"}}
+
+	t.Run("should decode by content type", func(t *testing.T) {
+		tests := []struct {
+			label       string
+			contentType string
+			body        string
+		}{
+			{"json", "application/json; charset=utf-8", `{"ID":1,"Name":"one"}`},
+			{"json without content type", "", `{"ID":1,"Name":"one"}`},
+			{"xml", "application/xml", `<formBody><id>1</id><name>one</name></formBody>`},
+			{"form", "application/x-www-form-urlencoded", "id=1&name=one"},
+		}
+
+		for _, test := range tests {
+			test := test
+			t.Run(test.label, func(t *testing.T) {
+				obj := bodyStruct{}
+				err := As(newRequest(t, test.contentType, test.body), &obj)
+
+				require.Nil(t, err)
+				assert.Equal(t, expected, obj)
+			})
+		}
+	})
+
+	t.Run("should decode multipart bodies as forms", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		w := multipart.NewWriter(&buf)
+		require.Nil(t, w.WriteField("id", "1"))
+		require.Nil(t, w.WriteField("name", "one"))
+		require.Nil(t, w.Close())
+
+		obj := bodyStruct{}
+		err := As(newRequest(t, w.FormDataContentType(), buf.String()), &obj)
+
+		require.Nil(t, err)
+		assert.Equal(t, expected, obj)
+	})
+
+	t.Run("should fail with a request body inside a form body", func(t *testing.T) {
+		type node struct {
+			Name  string `from:"form=name"`
+			Child *node  `from:"request-body"`
+		}
+
+		type nodeStruct struct {
+			Root node `from:"request-body"`
+		}
+
+		obj := nodeStruct{}
+		err := As(newRequest(t, "application/x-www-form-urlencoded", "name=one"), &obj)
+
+		var errs BindingErrors
+		require.ErrorAs(t, err, &errs)
+		require.Len(t, errs, 1)
+		assert.Equal(t, "Root", errs[0].Field)
+		assert.ErrorIs(t, err, ErrDuplicateBody)
+
+		obj = nodeStruct{}
+		err = As(newRequest(t, "application/json", `{"Name":"one","Child":{"Name":"two"}}`), &obj)
+
+		require.Nil(t, err)
+		assert.Equal(t, "two", obj.Root.Child.Name)
+	})
+
+	t.Run("should fail with unsupported media types", func(t *testing.T) {
+		for _, contentType := range []string{"text/csv", "application/json; charset"} {
+			obj := bodyStruct{}
+			err := As(newRequest(t, contentType, "1,one"), &obj)
+
+			var errs BindingErrors
+			require.ErrorAs(t, err, &errs)
+			require.Len(t, errs, 1)
+			assert.Equal(t, "Body", errs[0].Field)
+			assert.ErrorIs(t, err, ErrUnsupportedMediaType)
+		}
+	})
+
+	t.Run("should use registered decoders", func(t *testing.T) {
+		csv := func(r *http.Request, v any) error {
+			data, err := io.ReadAll(r.Body)
+			if err != nil {
+				return err
+			}
+
+			id, name, _ := strings.Cut(string(data), ",")
+			body := v.(*formBody)
+			body.Name = name
+			body.ID, err = strconv.ParseInt(id, 10, 64)
+			return err
+		}
+
+		obj := bodyStruct{}
+		err := As(newRequest(t, "text/csv", "1,one"), &obj, WithDecoder("text/csv", csv))
+
+		require.Nil(t, err)
+		assert.Equal(t, expected, obj)
+	})
+
+	t.Run("should prefer the unmarshaller when set", func(t *testing.T) {
+		obj := bodyStruct{}
+		err := As(newRequest(t, "text/csv", "1,one"), &obj, WithUnmarshaller(func(r *http.Request, v any) error {
+			*v.(*formBody) = formBody{ID: 1, Name: "one"}
+			return nil
+		}))
+
+		require.Nil(t, err)
+		assert.Equal(t, expected, obj)
+	})
+}
+
+func TestAsXML(t *testing.T) {
+	type item struct {
+		XMLName xml.Name `xml:"item"`
+		ID      int64    `xml:"id,attr"`
+		Name    string   `xml:"name"`
+	}
+
+	type xmlStruct struct {
+		Body *item `from:"request-body"`
+	}
+
+	newRequest := func(t *testing.T, contentType, body string) *http.Request {
+		req, reqErr := http.NewRequest("POST", "/", strings.NewReader(body))
+		require.Nil(t, reqErr)
+
+		req.Header.Set("Content-Type", contentType)
+		return req
+	}
+
+	t.Run("should decode xml media types", func(t *testing.T) {
+		for _, contentType := range []string{"application/xml", "text/xml; charset=utf-8", "application/atom+xml"} {
+			obj := xmlStruct{}
+			err := As(newRequest(t, contentType, `<item id="3"><name>three</name></item>`), &obj)
+
+			require.Nil(t, err, contentType)
+			require.NotNil(t, obj.Body, contentType)
+			assert.Equal(t, int64(3), obj.Body.ID, contentType)
+			assert.Equal(t, "three", obj.Body.Name, contentType)
+		}
+	})
+
+	t.Run("should report syntax errors like json", func(t *testing.T) {
+		obj := xmlStruct{}
+		err := As(newRequest(t, "application/xml", `<item id="3"><name>three</item>`), &obj)
+
+		var (
+			errs      BindingErrors
+			syntaxErr *xml.SyntaxError
+		)
+		require.ErrorAs(t, err, &errs)
+		require.Len(t, errs, 1)
+		assert.Equal(t, "Body", errs[0].Field)
+		assert.Equal(t, requestBodyTag, errs[0].Kind)
+		assert.ErrorAs(t, err, &syntaxErr)
+	})
+
+	t.Run("should fall back on suffix decoders", func(t *testing.T) {
+		obj := xmlStruct{}
+		err := As(newRequest(t, "application/problem+json", `{"ID":4,"Name":"four"}`), &obj)
+
+		require.Nil(t, err)
+		assert.Equal(t, &item{ID: 4, Name: "four"}, obj.Body)
+
+		err = As(newRequest(t, "application/vnd.api+yaml", "id: 4"), &obj)
+		assert.ErrorIs(t, err, ErrUnsupportedMediaType)
+	})
+}
+
+func TestAsStrictJSON(t *testing.T) {
+	type item struct {
+		ID    int64 `json:"id"`
+		Extra any   `json:"extra"`
+	}
+
+	type jsonStruct struct {
+		Body struct {
+			Items []item `json:"items"`
+		} `from:"request-body"`
+	}
+
+	newRequest := func(t *testing.T, body string) *http.Request {
+		req, reqErr := http.NewRequest("POST", "/", strings.NewReader(body))
+		require.Nil(t, reqErr)
+
+		req.Header.Set("Content-Type", "application/json")
+		return req
+	}
+
+	t.Run("should accept valid bodies", func(t *testing.T) {
+		obj := jsonStruct{}
+		err := As(newRequest(t, `{"items":[{"id":1,"extra":12345678901234567890}]} `), &obj, WithStrictJSON(true))
+
+		require.Nil(t, err)
+		require.Len(t, obj.Body.Items, 1)
+		assert.Equal(t, json.Number("12345678901234567890"), obj.Body.Items[0].Extra)
+	})
+
+	t.Run("should be lenient by default", func(t *testing.T) {
+		obj := jsonStruct{}
+		err := As(newRequest(t, `{"items":[],"unknown":true} trailing`), &obj)
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("should reject invalid bodies", func(t *testing.T) {
+		tests := []struct {
+			label  string
+			body   string
+			err    error
+			offset int64
+			path   string
+		}{
+			{"unknown field", `{"items":[],"unknown":true}`, nil, 0, ""},
+			{"trailing data", `{"items":[]} {}`, ErrTrailingData, 14, ""},
+			{"trailing garbage", `{"items":[]} x`, ErrTrailingData, 12, ""},
+			{"wrong type", `{"items":[{"id":"one"}]}`, nil, 21, "items.0.id"},
+		}
+
+		for _, test := range tests {
+			test := test
+			t.Run(test.label, func(t *testing.T) {
+				obj := jsonStruct{}
+				err := As(newRequest(t, test.body), &obj, WithStrictJSON(false))
+
+				var jsonErr *JSONError
+				require.ErrorAs(t, err, &jsonErr)
+				assert.Equal(t, test.path, jsonErr.Path)
+				if test.offset > 0 {
+					assert.Equal(t, test.offset, jsonErr.Offset)
+				} else {
+					assert.Positive(t, jsonErr.Offset)
+				}
+				if test.err != nil {
+					assert.ErrorIs(t, err, test.err)
+				}
+			})
+		}
+	})
+
+	t.Run("should report syntax errors by offset", func(t *testing.T) {
+		obj := jsonStruct{}
+		err := As(newRequest(t, `{"items" []}`), &obj, WithStrictJSON(false))
+
+		var (
+			jsonErr   *JSONError
+			syntaxErr *json.SyntaxError
+		)
+		require.ErrorAs(t, err, &jsonErr)
+		require.ErrorAs(t, err, &syntaxErr)
+		assert.Equal(t, syntaxErr.Offset, jsonErr.Offset)
+	})
+
+	t.Run("should report read errors after the value", func(t *testing.T) {
+		obj := jsonStruct{}
+		err := As(newRequest(t, `{"items":[]}`+strings.Repeat(" ", 100)), &obj, WithStrictJSON(false), WithMaxBodyBytes(20))
+
+		var jsonErr *JSONError
+		require.ErrorAs(t, err, &jsonErr)
+		assert.ErrorIs(t, err, ErrBodyTooLarge)
+		assert.NotErrorIs(t, err, ErrTrailingData)
+	})
+}
+
+func TestAsMaxBodyBytes(t *testing.T) {
+	type sizedStruct struct {
+		Body testBody `from:"request-body"`
+	}
+
+	type tightStruct struct {
+		Body testBody `from:"request-body,max=16B"`
+	}
+
+	body := `{"id":1,"name":"a long enough name"}`
+	newRequest := func(t *testing.T, contentType string) *http.Request {
+		req, reqErr := http.NewRequest("POST", "/", strings.NewReader(body))
+		require.Nil(t, reqErr)
+
+		req.Header.Set("Content-Type", contentType)
+		return req
+	}
+
+	t.Run("should decode bodies within the limit", func(t *testing.T) {
+		obj := sizedStruct{}
+		err := As(newRequest(t, "application/json"), &obj, WithMaxBodyBytes(int64(len(body))))
+
+		require.Nil(t, err)
+		assert.Equal(t, "a long enough name", obj.Body.Name)
+	})
+
+	t.Run("should fail past the limit", func(t *testing.T) {
+		for _, contentType := range []string{"application/json", "application/xml"} {
+			obj := sizedStruct{}
+			err := As(newRequest(t, contentType), &obj, WithMaxBodyBytes(int64(len(body)-1)))
+
+			var errs BindingErrors
+			require.ErrorAs(t, err, &errs, contentType)
+			assert.Equal(t, "Body", errs[0].Field, contentType)
+			assert.ErrorIs(t, err, ErrBodyTooLarge, contentType)
+		}
+	})
+
+	t.Run("should apply the limit to unmarshallers", func(t *testing.T) {
+		obj := sizedStruct{}
+		err := As(newRequest(t, "text/plain"), &obj, WithMaxBodyBytes(8), WithUnmarshaller(func(r *http.Request, v any) error {
+			_, err := io.ReadAll(r.Body)
+			return err
+		}))
+
+		assert.ErrorIs(t, err, ErrBodyTooLarge)
+	})
+
+	t.Run("should let the tag override the option", func(t *testing.T) {
+		obj := tightStruct{}
+		err := As(newRequest(t, "application/json"), &obj, WithMaxBodyBytes(1<<20))
+
+		assert.ErrorIs(t, err, ErrBodyTooLarge)
+	})
+
+	t.Run("should reject invalid sizes", func(t *testing.T) {
+		type invalidStruct struct {
+			Body testBody `from:"request-body,max=lots"`
+		}
+
+		obj := invalidStruct{}
+		err := As(newRequest(t, "application/json"), &obj)
+
+		var errs BindingErrors
+		require.ErrorAs(t, err, &errs)
+		require.Len(t, errs, 1)
+		assert.ErrorIs(t, err, ErrInvalidSize)
+	})
+}
+
+func TestAsContentEncoding(t *testing.T) {
+	type bodyStruct struct {
+		Body testBody `from:"request-body"`
+	}
+
+	compress := func(t *testing.T, encoding string, data []byte) []byte {
+		var (
+			buf bytes.Buffer
+			w   io.WriteCloser
+		)
+
+		switch encoding {
+		case "gzip":
+			w = gzip.NewWriter(&buf)
+		case "deflate":
+			w = zlib.NewWriter(&buf)
+		case "base64":
+			w = base64.NewEncoder(base64.StdEncoding, &buf)
+		}
+		_, err := w.Write(data)
+		require.Nil(t, err)
+		require.Nil(t, w.Close())
+		return buf.Bytes()
+	}
+
+	unbase64 := func(r io.Reader) (io.ReadCloser, error) {
+		return io.NopCloser(base64.NewDecoder(base64.StdEncoding, r)), nil
+	}
+
+	newRequest := func(t *testing.T, encoding string, body []byte) *http.Request {
+		req, reqErr := http.NewRequest("POST", "/", bytes.NewReader(body))
+		require.Nil(t, reqErr)
+
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Content-Encoding", encoding)
+		return req
+	}
+
+	body := []byte(`{"id":5,"name":"five"}`)
+
+	t.Run("should decompress built-in encodings", func(t *testing.T) {
+		for _, encoding := range []string{"gzip", "deflate"} {
+			obj := bodyStruct{}
+			err := As(newRequest(t, encoding, compress(t, encoding, body)), &obj)
+
+			require.Nil(t, err, encoding)
+			assert.Equal(t, testBody{ID: 5, Name: "five"}, obj.Body, encoding)
+		}
+	})
+
+	t.Run("should undo encodings in reverse order", func(t *testing.T) {
+		data := compress(t, "base64", compress(t, "gzip", body))
+
+		obj := bodyStruct{}
+		err := As(newRequest(t, "gzip, base64", data), &obj, WithDecompressor("base64", unbase64))
+
+		require.Nil(t, err)
+		assert.Equal(t, testBody{ID: 5, Name: "five"}, obj.Body)
+	})
+
+	t.Run("should hide the encoding from unmarshallers", func(t *testing.T) {
+		var encoding []string
+
+		obj := bodyStruct{}
+		err := As(newRequest(t, "gzip", compress(t, "gzip", body)), &obj, WithUnmarshaller(func(r *http.Request, v any) error {
+			encoding = r.Header.Values("Content-Encoding")
+			return json.NewDecoder(r.Body).Decode(v)
+		}))
+
+		require.Nil(t, err)
+		assert.Empty(t, encoding)
+		assert.Equal(t, testBody{ID: 5, Name: "five"}, obj.Body)
+	})
+
+	t.Run("should limit the decompressed body", func(t *testing.T) {
+		bomb := []byte(`{"name":"` + strings.Repeat("a", 1<<20) + `"}`)
+		data := compress(t, "gzip", bomb)
+		require.Less(t, len(data), 4<<10)
+
+		obj := bodyStruct{}
+		err := As(newRequest(t, "gzip", data), &obj, WithMaxBodyBytes(64<<10))
+
+		assert.ErrorIs(t, err, ErrBodyTooLarge)
+	})
+
+	t.Run("should fail with unsupported or corrupt encodings", func(t *testing.T) {
+		obj := bodyStruct{}
+		err := As(newRequest(t, "br", body), &obj)
+
+		assert.ErrorIs(t, err, ErrUnsupportedEncoding)
+
+		err = As(newRequest(t, "gzip", body), &obj)
+
+		assert.ErrorIs(t, err, gzip.ErrHeader)
+	})
+
+	t.Run("should decompress form bodies", func(t *testing.T) {
+		type formBody struct {
+			Name string `from:"form=name"`
+			Age  int    `from:"form=age"`
+		}
+
+		type formStruct struct {
+			Body formBody `from:"request-body"`
+		}
+
+		newFormRequest := func(t *testing.T) *http.Request {
+			req := newRequest(t, "gzip", compress(t, "gzip", []byte("name=bob&age=3")))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			return req
+		}
+
+		obj := formStruct{}
+		err := As(newFormRequest(t), &obj)
+
+		require.Nil(t, err)
+		assert.Equal(t, formBody{Name: "bob", Age: 3}, obj.Body)
+
+		fields := formBody{}
+		err = As(newFormRequest(t), &fields)
+
+		require.Nil(t, err)
+		assert.Equal(t, formBody{Name: "bob", Age: 3}, fields)
+	})
+
+	t.Run("should fail with unsupported form encodings", func(t *testing.T) {
+		type formStruct struct {
+			Name string `from:"form=name"`
+		}
+
+		req := newRequest(t, "br", []byte("name=bob"))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+		obj := formStruct{}
+		err := As(req, &obj)
+
+		assert.ErrorIs(t, err, ErrUnsupportedEncoding)
+		assert.Equal(t, "", obj.Name)
+	})
+}
+
+func TestParseSize(t *testing.T) {
+	tests := map[string]int64{
+		"512":   512,
+		"16B":   16,
+		"64KB":  64 << 10,
+		"1MB":   1 << 20,
+		"2mb":   2 << 20,
+		"1 GB":  1 << 30,
+		"":      0,
+		"MB":    0,
+		"-1KB":  0,
+		"1.5MB": 0,
+		"1TB":   0,
+	}
+
+	for size, expected := range tests {
+		n, err := parseSize(size)
+		if expected == 0 {
+			assert.ErrorIs(t, err, ErrInvalidSize, size)
+		} else {
+			assert.Nil(t, err, size)
+			assert.Equal(t, expected, n, size)
+		}
+	}
+}
+
+func TestAsHeader(t *testing.T) {
+	type headerStruct struct {
+		RequestID string `from:"header=x-request-id"`
+		Retries   int    `from:"header=X-Retries"`
+		Missing   string `from:"header=X-Missing"`
+	}
+
+	t.Run("should succeed using canonical header names", func(t *testing.T) {
+		req, reqErr := http.NewRequest("GET", "/hello/world", nil)
+		require.Nil(t, reqErr)
+
+		req.Header.Set("X-Request-Id", "abc-123")
+		req.Header.Set("x-retries", "3")
+
+		obj := headerStruct{}
+		err := As(req, &obj)
+
+		assert.Nil(t, err)
+		assert.Equal(t, "abc-123", obj.RequestID)
+		assert.Equal(t, 3, obj.Retries)
+		assert.Equal(t, "", obj.Missing)
+	})
+
+	t.Run("should succeed with a custom header func", func(t *testing.T) {
+		req, reqErr := http.NewRequest("GET", "/hello/world", nil)
+		require.Nil(t, reqErr)
+
+		obj := headerStruct{}
+		err := As(
+			req,
+			&obj,
+			WithHeaderFunc(func(r *http.Request) http.Header {
+				h := http.Header{}
+				h.Set("X-Request-ID", "from-func")
+				return h
+			}),
+		)
+
+		assert.Nil(t, err)
+		assert.Equal(t, "from-func", obj.RequestID)
+	})
+}
+
+func TestAsCookie(t *testing.T) {
+	type cookieStruct struct {
+		Session string       `from:"cookie=session"`
+		Count   int          `from:"cookie=count"`
+		Raw     http.Cookie  `from:"cookie=session"`
+		Ptr     *http.Cookie `from:"cookie=count"`
+	}
+
+	t.Run("should succeed", func(t *testing.T) {
+		req, reqErr := http.NewRequest("GET", "/hello/world", nil)
+		require.Nil(t, reqErr)
+
+		req.AddCookie(&http.Cookie{Name: "session", Value: "s3cr3t"})
+		req.AddCookie(&http.Cookie{Name: "count", Value: "7"})
+
+		obj := cookieStruct{}
+		err := As(req, &obj)
+
+		assert.Nil(t, err)
+		assert.Equal(t, "s3cr3t", obj.Session)
+		assert.Equal(t, 7, obj.Count)
+		assert.Equal(t, "session", obj.Raw.Name)
+		assert.Equal(t, "s3cr3t", obj.Raw.Value)
+		require.NotNil(t, obj.Ptr)
+		assert.Equal(t, "count", obj.Ptr.Name)
+		assert.Equal(t, "7", obj.Ptr.Value)
+	})
+
+	t.Run("should fail with a missing cookie", func(t *testing.T) {
+		req, reqErr := http.NewRequest("GET", "/hello/world", nil)
+		require.Nil(t, reqErr)
+
+		req.AddCookie(&http.Cookie{Name: "session", Value: "s3cr3t"})
+
+		obj := cookieStruct{}
+		err := As(req, &obj)
+
+		assert.True(t, errors.Is(err, http.ErrNoCookie))
+	})
+
+	t.Run("should fail with an invalid cookie value", func(t *testing.T) {
+		req, reqErr := http.NewRequest("GET", "/hello/world", nil)
+		require.Nil(t, reqErr)
+
+		req.AddCookie(&http.Cookie{Name: "session", Value: "s3cr3t"})
+		req.AddCookie(&http.Cookie{Name: "count", Value: "seven"})
+
+		obj := cookieStruct{}
+		err := As(req, &obj)
+
+		assert.NotNil(t, err)
+	})
+}
+
+func TestAsForm(t *testing.T) {
+	type formStruct struct {
+		Name  string `from:"form=name"`
+		Age   int    `from:"form=age"`
+		Admin bool   `from:"form=admin"`
+		Sort  string `from:"url-query=sort"`
+	}
+
+	t.Run("should succeed", func(t *testing.T) {
+		body := strings.NewReader("name=Jane&age=42&admin=true&sort=body")
+		req, reqErr := http.NewRequest("POST", "/hello/world?sort=name", body)
+		require.Nil(t, reqErr)
+
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+		obj := formStruct{}
+		err := As(req, &obj)
+
+		assert.Nil(t, err)
+		assert.Equal(t, "Jane", obj.Name)
+		assert.Equal(t, 42, obj.Age)
+		assert.True(t, obj.Admin)
+		assert.Equal(t, "name", obj.Sort)
+	})
+
+	t.Run("should succeed with a custom form func called once", func(t *testing.T) {
+		req, reqErr := http.NewRequest("POST", "/hello/world", nil)
+		require.Nil(t, reqErr)
+
+		calls := 0
+		obj := formStruct{}
+		err := As(
+			req,
+			&obj,
+			WithFormFunc(func(r *http.Request) (url.Values, error) {
+				calls++
+				return url.Values{
+					"name": []string{"John"},
+					"age":  []string{"7"},
+				}, nil
+			}),
+		)
+
+		assert.Nil(t, err)
+		assert.Equal(t, 1, calls)
+		assert.Equal(t, "John", obj.Name)
+		assert.Equal(t, 7, obj.Age)
+	})
+
+	t.Run("should fail when the form cannot be parsed", func(t *testing.T) {
+		req, reqErr := http.NewRequest("POST", "/hello/world", nil)
+		require.Nil(t, reqErr)
+
+		expected := errors.New("form error")
+		obj := formStruct{}
+		err := As(
+			req,
+			&obj,
+			WithFormFunc(func(r *http.Request) (url.Values, error) {
+				return nil, expected
+			}),
+		)
+
+		assert.True(t, errors.Is(err, expected))
+	})
+}
+
+func TestAsMultipart(t *testing.T) {
+	type multipartStruct struct {
+		Title   string                  `from:"form=title"`
+		Count   int                     `from:"form=count"`
+		Avatar  *multipart.FileHeader   `from:"file=avatar"`
+		Photos  []*multipart.FileHeader `from:"file=photos"`
+		Reader  io.ReadCloser           `from:"file=avatar"`
+		Content []byte                  `from:"file=avatar"`
+	}
+
+	newRequest := func(t *testing.T) *http.Request {
+		var buf bytes.Buffer
+
+		w := multipart.NewWriter(&buf)
+		require.Nil(t, w.WriteField("title", "Holidays"))
+		require.Nil(t, w.WriteField("count", "2"))
+
+		files := []struct{ field, name, content string }{
+			{"avatar", "me.png", "avatar-bytes"},
+			{"photos", "a.png", "photo-a"},
+			{"photos", "b.png", "photo-b"},
+		}
+		for _, file := range files {
+			part, err := w.CreateFormFile(file.field, file.name)
+			require.Nil(t, err)
+			_, err = part.Write([]byte(file.content))
+			require.Nil(t, err)
+		}
+		require.Nil(t, w.Close())
+
+		req, err := http.NewRequest("POST", "/upload", &buf)
+		require.Nil(t, err)
+
+		req.Header.Set("Content-Type", w.FormDataContentType())
+		return req
+	}
+
+	t.Run("should succeed", func(t *testing.T) {
+		obj := multipartStruct{}
+		err := As(newRequest(t), &obj)
+
+		require.Nil(t, err)
+		assert.Equal(t, "Holidays", obj.Title)
+		assert.Equal(t, 2, obj.Count)
+		require.NotNil(t, obj.Avatar)
+		assert.Equal(t, "me.png", obj.Avatar.Filename)
+		require.Len(t, obj.Photos, 2)
+		assert.Equal(t, "b.png", obj.Photos[1].Filename)
+		assert.Equal(t, []byte("avatar-bytes"), obj.Content)
+
+		require.NotNil(t, obj.Reader)
+		data, readErr := io.ReadAll(obj.Reader)
+		assert.Nil(t, readErr)
+		assert.Equal(t, []byte("avatar-bytes"), data)
+		assert.Nil(t, obj.Reader.Close())
+	})
+
+	t.Run("should fail when a file is too large", func(t *testing.T) {
+		obj := multipartStruct{}
+		err := As(newRequest(t), &obj, WithMaxFileSize(8))
+
+		assert.True(t, errors.Is(err, ErrFileTooLarge))
+		assert.Contains(t, err.Error(), "avatar")
+	})
+
+	t.Run("should fail when the upload is too large", func(t *testing.T) {
+		obj := multipartStruct{}
+		err := As(newRequest(t), &obj, WithMaxUploadSize(16))
+
+		assert.True(t, errors.Is(err, ErrUploadTooLarge))
+	})
+
+	t.Run("should check the limits for every field", func(t *testing.T) {
+		type sameFileStruct struct {
+			Header  *multipart.FileHeader `from:"file=avatar"`
+			Content []byte                `from:"file=avatar"`
+		}
+
+		obj := sameFileStruct{}
+		err := As(newRequest(t), &obj, WithMaxFileSize(8))
+
+		var errs BindingErrors
+		require.ErrorAs(t, err, &errs)
+		require.Len(t, errs, 2)
+		assert.ErrorIs(t, errs[0], ErrFileTooLarge)
+		assert.ErrorIs(t, errs[1], ErrFileTooLarge)
+		assert.Nil(t, obj.Content)
+	})
+
+	t.Run("should check the limits of forms parsed before", func(t *testing.T) {
+		req := newRequest(t)
+		require.Nil(t, req.ParseMultipartForm(32<<20))
+
+		obj := multipartStruct{}
+		err := As(req, &obj, WithMaxFileSize(8))
+
+		assert.ErrorIs(t, err, ErrFileTooLarge)
+		assert.Nil(t, obj.Content)
+	})
+
+	t.Run("should stop reading uploads at the limit", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		w := multipart.NewWriter(&buf)
+		part, err := w.CreateFormFile("avatar", "big.png")
+		require.Nil(t, err)
+		_, err = part.Write(bytes.Repeat([]byte("a"), 1<<20))
+		require.Nil(t, err)
+		require.Nil(t, w.Close())
+
+		body := &countingReader{r: &buf}
+		req, reqErr := http.NewRequest("POST", "/upload", body)
+		require.Nil(t, reqErr)
+
+		req.Header.Set("Content-Type", w.FormDataContentType())
+
+		obj := multipartStruct{}
+		err = As(req, &obj, WithMaxUploadSize(1<<10))
+
+		assert.ErrorIs(t, err, ErrUploadTooLarge)
+		assert.Less(t, body.n, 64<<10)
+	})
+
+	t.Run("should bind uploads past the body limit", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		w := multipart.NewWriter(&buf)
+		require.Nil(t, w.WriteField("title", "Holidays"))
+		part, err := w.CreateFormFile("avatar", "big.png")
+		require.Nil(t, err)
+		_, err = part.Write(bytes.Repeat([]byte("a"), 5<<10))
+		require.Nil(t, err)
+		require.Nil(t, w.Close())
+
+		req, reqErr := http.NewRequest("POST", "/upload", &buf)
+		require.Nil(t, reqErr)
+
+		req.Header.Set("Content-Type", w.FormDataContentType())
+
+		type uploadStruct struct {
+			Title  string                `from:"form=title"`
+			Avatar *multipart.FileHeader `from:"file=avatar"`
+		}
+
+		obj := uploadStruct{}
+		err = As(req, &obj, WithMaxBodyBytes(1<<10), WithMaxUploadSize(1<<20))
+
+		require.Nil(t, err)
+		assert.Equal(t, "Holidays", obj.Title)
+		assert.Equal(t, int64(5<<10), obj.Avatar.Size)
+	})
+
+	t.Run("should fail when a file is missing", func(t *testing.T) {
+		type missingStruct struct {
+			Doc *multipart.FileHeader `from:"file=doc"`
+		}
+
+		obj := missingStruct{}
+		err := As(newRequest(t), &obj)
+
+		assert.True(t, errors.Is(err, http.ErrMissingFile))
+		assert.Contains(t, err.Error(), "doc")
+	})
+
+	t.Run("should fail when the request is not multipart", func(t *testing.T) {
+		req, reqErr := http.NewRequest("POST", "/upload", nil)
+		require.Nil(t, reqErr)
+
+		obj := multipartStruct{}
+		err := As(req, &obj, WithFormFunc(func(r *http.Request) (url.Values, error) {
+			return url.Values{}, nil
+		}))
+
+		assert.True(t, errors.Is(err, ErrNotMultipart))
+	})
+}
+
+type countingReader struct {
+	r io.Reader
+	n int
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += n
+	return n, err
+}
+
+func TestAsConversionError(t *testing.T) {
+	type conversionStruct struct {
+		ID    int64     `from:"url-param=id"`
+		Limit int       `from:"url-query=limit"`
+		Since time.Time `from:"url-query=since,layout=DateOnly"`
+	}
+
+	t.Run("should report the query field that failed", func(t *testing.T) {
+		req, reqErr := http.NewRequest("GET", "/hello/world?limit=abc", nil)
+		require.Nil(t, reqErr)
+
+		obj := conversionStruct{}
+		err := As(req, &obj)
+
+		var fieldErr *FieldError
+		require.True(t, errors.As(err, &fieldErr))
+		assert.Equal(t, "Limit", fieldErr.Field)
+		assert.Equal(t, urlQueryTag, fieldErr.Kind)
+		assert.Equal(t, "limit", fieldErr.Source)
+		assert.Equal(t, "abc", fieldErr.Value)
+		assert.True(t, errors.Is(err, strconv.ErrSyntax))
+	})
+
+	t.Run("should report the url param that failed", func(t *testing.T) {
+		req, reqErr := http.NewRequest("GET", "/hello/world", nil)
+		require.Nil(t, reqErr)
+
+		obj := conversionStruct{}
+		err := As(req, &obj, WithURLParamFunc(func(r *http.Request, key string) string {
+			return "ten"
+		}))
+
+		var fieldErr *FieldError
+		require.True(t, errors.As(err, &fieldErr))
+		assert.Equal(t, "ID", fieldErr.Field)
+		assert.Equal(t, urlParamTag, fieldErr.Kind)
+		assert.Equal(t, "id", fieldErr.Source)
+		assert.Equal(t, "ten", fieldErr.Value)
+	})
+
+	t.Run("should report time parsing errors", func(t *testing.T) {
+		req, reqErr := http.NewRequest("GET", "/hello/world?since=yesterday", nil)
+		require.Nil(t, reqErr)
+
+		obj := conversionStruct{}
+		err := As(req, &obj)
+
+		var fieldErr *FieldError
+		var parseErr *time.ParseError
+		require.True(t, errors.As(err, &fieldErr))
+		assert.Equal(t, "Since", fieldErr.Field)
+		assert.True(t, errors.As(err, &parseErr))
+	})
+
+	t.Run("should ignore missing values", func(t *testing.T) {
+		req, reqErr := http.NewRequest("GET", "/hello/world", nil)
+		require.Nil(t, reqErr)
+
+		obj := conversionStruct{}
+		err := As(req, &obj)
+
+		assert.Nil(t, err)
+		assert.Equal(t, 0, obj.Limit)
+	})
+}
+
+func TestAsBindingErrors(t *testing.T) {
+	type manyStruct struct {
+		Page  int     `from:"url-query=page"`
+		Limit int     `from:"url-query=limit"`
+		Ratio float64 `from:"url-query=ratio"`
+		Name  string  `from:"url-query=name"`
+	}
+
+	t.Run("should report every failed field", func(t *testing.T) {
+		req, reqErr := http.NewRequest("GET", "/hello/world?page=x&limit=y&ratio=z&name=ok", nil)
+		require.Nil(t, reqErr)
+
+		obj := manyStruct{}
+		err := As(req, &obj)
+
+		var errs BindingErrors
+		require.True(t, errors.As(err, &errs))
+		require.Len(t, errs, 3)
+		assert.Equal(t, "Page", errs[0].Field)
+		assert.Equal(t, "Limit", errs[1].Field)
+		assert.Equal(t, "Ratio", errs[2].Field)
+		assert.Equal(t, "ok", obj.Name)
+		assert.True(t, errors.Is(err, strconv.ErrSyntax))
+	})
+
+	t.Run("should stop at the first error when failing fast", func(t *testing.T) {
+		req, reqErr := http.NewRequest("GET", "/hello/world?page=x&limit=y&ratio=z&name=ok", nil)
+		require.Nil(t, reqErr)
+
+		obj := manyStruct{}
+		err := As(req, &obj, WithFailFast())
+
+		var errs BindingErrors
+		require.True(t, errors.As(err, &errs))
+		require.Len(t, errs, 1)
+		assert.Equal(t, "Page", errs[0].Field)
+		assert.Equal(t, "", obj.Name)
+	})
+}
+
+func TestAsInvalidTargetsAndTags(t *testing.T) {
+	req, reqErr := http.NewRequest("GET", "/hello/world?name=ok", nil)
+	require.Nil(t, reqErr)
+
+	t.Run("should fail with invalid targets", func(t *testing.T) {
+		var nilPtr *testStruct
+
+		targets := []any{nil, testStruct{}, nilPtr, new(int)}
+		for _, target := range targets {
+			err := As(req, target)
+			assert.True(t, errors.Is(err, ErrInvalidTarget))
+		}
+	})
+
+	t.Run("should fail with an unknown source kind before binding", func(t *testing.T) {
+		type unknownStruct struct {
+			Name  string `from:"url-query=name"`
+			Other string `from:"somewhere=other"`
+		}
+
+		obj := unknownStruct{}
+		err := As(req, &obj)
+
+		var fieldErr *FieldError
+		require.True(t, errors.As(err, &fieldErr))
+		assert.Equal(t, "Other", fieldErr.Field)
+		assert.True(t, errors.Is(err, ErrUnknownSourceKind))
+		assert.Equal(t, "", obj.Name)
+	})
+
+	t.Run("should fail with a duplicate request body", func(t *testing.T) {
+		type duplicateStruct struct {
+			Name   string   `from:"url-query=name"`
+			First  testBody `from:"request-body"`
+			Second testBody `from:"request-body"`
+		}
+
+		obj := duplicateStruct{}
+		err := As(req, &obj)
+
+		var fieldErr *FieldError
+		require.True(t, errors.As(err, &fieldErr))
+		assert.Equal(t, "Second", fieldErr.Field)
+		assert.True(t, errors.Is(err, ErrDuplicateBody))
+		assert.Equal(t, "", obj.Name)
+	})
+
+	t.Run("should report every malformed tag", func(t *testing.T) {
+		type malformedStruct struct {
+			Name   string `from:"url-query=name"`
+			Broken string `from:"url-query"`
+			File   int    `from:"file=upload"`
+		}
+
+		obj := malformedStruct{}
+		err := As(req, &obj)
+
+		var errs BindingErrors
+		require.True(t, errors.As(err, &errs))
+		require.Len(t, errs, 2)
+		assert.True(t, errors.Is(errs[0], ErrInvalidParamTagKeyValue))
+		assert.True(t, errors.Is(errs[1], ErrInvalidFileField))
+		assert.Equal(t, "", obj.Name)
+	})
+}
+
+func TestAsSlices(t *testing.T) {
+	type sliceStruct struct {
+		Tags    []string  `from:"url-query=tag"`
+		IDs     []int64   `from:"url-query=ids,split=comma"`
+		Point   [2]int    `from:"url-query=point,split=comma"`
+		Langs   []string  `from:"header=Accept-Language"`
+		Choices []bool    `from:"form=choice"`
+		Empty   []float32 `from:"url-query=empty"`
+	}
+
+	t.Run("should succeed", func(t *testing.T) {
+		body := strings.NewReader("choice=true&choice=false")
+		req, reqErr := http.NewRequest("POST", "/hello/world?tag=a&tag=b&ids=1,2,3&ids=4&point=7,8", body)
+		require.Nil(t, reqErr)
+
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.Header.Add("Accept-Language", "pt-BR")
+		req.Header.Add("Accept-Language", "en")
+
+		obj := sliceStruct{}
+		err := As(req, &obj)
+
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"a", "b"}, obj.Tags)
+		assert.Equal(t, []int64{1, 2, 3, 4}, obj.IDs)
+		assert.Equal(t, [2]int{7, 8}, obj.Point)
+		assert.Equal(t, []string{"pt-BR", "en"}, obj.Langs)
+		assert.Equal(t, []bool{true, false}, obj.Choices)
+		assert.Nil(t, obj.Empty)
+	})
+
+	t.Run("should fail with the wrong array length", func(t *testing.T) {
+		req, reqErr := http.NewRequest("GET", "/hello/world?point=7,8,9", nil)
+		require.Nil(t, reqErr)
+
+		obj := sliceStruct{}
+		err := As(req, &obj)
+
+		var fieldErr *FieldError
+		require.True(t, errors.As(err, &fieldErr))
+		assert.Equal(t, "Point", fieldErr.Field)
+		assert.Equal(t, "7,8,9", fieldErr.Value)
+		assert.True(t, errors.Is(err, ErrArrayLength))
+	})
+}
+
+func TestAsPointers(t *testing.T) {
+	type pointerStruct struct {
+		ID     *int64     `from:"url-param=id"`
+		Active *bool      `from:"url-query=active"`
+		Name   *string    `from:"url-query=name"`
+		Since  *time.Time `from:"url-query=since,layout=DateOnly"`
+		Tenant *string    `from:"header=X-Tenant"`
+		Theme  *string    `from:"cookie=theme"`
+		Count  *int       `from:"form=count"`
+	}
+
+	t.Run("should stay nil when values are missing", func(t *testing.T) {
+		req, reqErr := http.NewRequest("POST", "/hello/world", strings.NewReader(""))
+		require.Nil(t, reqErr)
+
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+		obj := pointerStruct{}
+		err := As(req, &obj)
+
+		assert.Nil(t, err)
+		assert.Nil(t, obj.ID)
+		assert.Nil(t, obj.Active)
+		assert.Nil(t, obj.Name)
+		assert.Nil(t, obj.Since)
+		assert.Nil(t, obj.Tenant)
+		assert.Nil(t, obj.Theme)
+		assert.Nil(t, obj.Count)
+	})
+
+	t.Run("should allocate when values are present", func(t *testing.T) {
+		body := strings.NewReader("count=0")
+		req, reqErr := http.NewRequest("POST", "/hello/world?active=false&name=&since=2024-05-06", body)
+		require.Nil(t, reqErr)
+
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.Header.Set("X-Tenant", "acme")
+		req.AddCookie(&http.Cookie{Name: "theme", Value: "dark"})
+
+		obj := pointerStruct{}
+		err := As(req, &obj, WithURLParamFunc(func(r *http.Request, key string) string {
+			return "0"
+		}))
+
+		require.Nil(t, err)
+		require.NotNil(t, obj.ID)
+		require.NotNil(t, obj.Active)
+		require.NotNil(t, obj.Name)
+		require.NotNil(t, obj.Since)
+		require.NotNil(t, obj.Tenant)
+		require.NotNil(t, obj.Theme)
+		require.NotNil(t, obj.Count)
+		assert.Equal(t, int64(0), *obj.ID)
+		assert.False(t, *obj.Active)
+		assert.Equal(t, "", *obj.Name)
+		assert.Equal(t, time.May, obj.Since.Month())
+		assert.Equal(t, "acme", *obj.Tenant)
+		assert.Equal(t, "dark", *obj.Theme)
+		assert.Equal(t, 0, *obj.Count)
+	})
+}
+
+func TestAsEmbedded(t *testing.T) {
+	type listStruct struct {
+		Pagination
+		*Filters
+
+		Sort string `from:"url-query=sort"`
+	}
+
+	t.Run("should bind promoted fields", func(t *testing.T) {
+		body := strings.NewReader(`{"id":1,"name":"body"}`)
+		req, reqErr := http.NewRequest("POST", "/?limit=10&offset=20&owner=ana&sort=name", body)
+		require.Nil(t, reqErr)
+
+		obj := listStruct{}
+		err := As(req, &obj)
+
+		require.Nil(t, err)
+		assert.Equal(t, Pagination{Limit: 10, Offset: 20}, obj.Pagination)
+		require.NotNil(t, obj.Filters)
+		assert.Equal(t, "ana", obj.Owner)
+		assert.Equal(t, &testBody{ID: 1, Name: "body"}, obj.Filters.Body)
+		assert.Equal(t, "name", obj.Sort)
+	})
+
+	t.Run("should only allocate embedded pointers when setting", func(t *testing.T) {
+		type Sorting struct {
+			Sort string `from:"url-query=sort"`
+		}
+
+		type queryStruct struct {
+			Pagination
+			*Sorting
+		}
+
+		req, reqErr := http.NewRequest("GET", "/?limit=10", nil)
+		require.Nil(t, reqErr)
+
+		obj := queryStruct{}
+		err := As(req, &obj)
+
+		require.Nil(t, err)
+		assert.Equal(t, 10, obj.Limit)
+		assert.Nil(t, obj.Sorting)
+	})
+
+	t.Run("should name fields by their path", func(t *testing.T) {
+		req, reqErr := http.NewRequest("POST", "/?limit=ten", strings.NewReader("{}"))
+		require.Nil(t, reqErr)
+
+		obj := listStruct{}
+		err := As(req, &obj)
+
+		var errs BindingErrors
+		require.ErrorAs(t, err, &errs)
+		require.Len(t, errs, 1)
+		assert.Equal(t, "Pagination.Limit", errs[0].Field)
+	})
+
+	t.Run("should reject unexported fields", func(t *testing.T) {
+		type unexportedStruct struct {
+			*filters
+
+			name string `from:"url-query=name"`
+		}
+
+		req, reqErr := http.NewRequest("GET", "/?owner=ana&name=x", nil)
+		require.Nil(t, reqErr)
+
+		obj := unexportedStruct{}
+		err := As(req, &obj)
+
+		var errs BindingErrors
+		require.ErrorAs(t, err, &errs)
+		require.Len(t, errs, 2)
+		assert.Equal(t, "filters.Owner", errs[0].Field)
+		assert.ErrorIs(t, errs[0], ErrUnexportedField)
+		assert.Equal(t, "name", errs[1].Field)
+		assert.ErrorIs(t, errs[1], ErrUnexportedField)
+		assert.Equal(t, "", obj.name)
+	})
+}
+
+func TestAsGroups(t *testing.T) {
+	type dateRange struct {
+		From string  `from:"url-query=from"`
+		To   *string `from:"url-query=to"`
+	}
+
+	type auditStruct struct {
+		Created dateRange  `from:"group,prefix=created."`
+		Updated *dateRange `from:"group,prefix=updated."`
+	}
+
+	type searchStruct struct {
+		Audit  auditStruct `from:"group,prefix=audit."`
+		Filter struct {
+			Status string `from:"url-query=status"`
+			Tenant string `from:"header=X-Tenant"`
+			Theme  string `from:"cookie=theme"`
+			Page   int    `from:"form=page"`
+		} `from:"group,prefix=filter."`
+		Plain dateRange `from:"group"`
+	}
+
+	t.Run("should prefix the sources of grouped fields", func(t *testing.T) {
+		body := strings.NewReader("filter.page=3")
+		req, reqErr := http.NewRequest("POST", "/?audit.created.from=a&audit.created.to=b&filter.status=open&from=c", body)
+		require.Nil(t, reqErr)
+
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.Header.Set("filter.X-Tenant", "acme")
+		req.AddCookie(&http.Cookie{Name: "filter.theme", Value: "dark"})
+
+		obj := searchStruct{}
+		err := As(req, &obj)
+
+		require.Nil(t, err)
+		assert.Equal(t, "a", obj.Audit.Created.From)
+		require.NotNil(t, obj.Audit.Created.To)
+		assert.Equal(t, "b", *obj.Audit.Created.To)
+		assert.Nil(t, obj.Audit.Updated)
+		assert.Equal(t, "open", obj.Filter.Status)
+		assert.Equal(t, "acme", obj.Filter.Tenant)
+		assert.Equal(t, "dark", obj.Filter.Theme)
+		assert.Equal(t, 3, obj.Filter.Page)
+		assert.Equal(t, "c", obj.Plain.From)
+	})
+
+	t.Run("should allocate pointer groups when a field is set", func(t *testing.T) {
+		req, reqErr := http.NewRequest("GET", "/?updated.to=z", nil)
+		require.Nil(t, reqErr)
+
+		obj := auditStruct{}
+		err := As(req, &obj)
+
+		require.Nil(t, err)
+		require.NotNil(t, obj.Updated)
+		require.NotNil(t, obj.Updated.To)
+		assert.Equal(t, "z", *obj.Updated.To)
+	})
+
+	t.Run("should not bind embedded groups twice", func(t *testing.T) {
+		type Range struct {
+			From string `from:"url-query=from"`
+		}
+
+		type embeddedStruct struct {
+			Range `from:"group,prefix=range."`
+		}
+
+		req, reqErr := http.NewRequest("GET", "/?from=a&range.from=b", nil)
+		require.Nil(t, reqErr)
+
+		obj := embeddedStruct{}
+		err := As(req, &obj)
+
+		require.Nil(t, err)
+		assert.Equal(t, "b", obj.From)
+		assert.Len(t, defaultBinder.cachedPlan(reflect.TypeOf(obj)).fields, 1)
+	})
+
+	t.Run("should name errors by path and prefixed source", func(t *testing.T) {
+		type pageStruct struct {
+			Page struct {
+				Limit int `from:"url-query=limit"`
+			} `from:"group,prefix=page."`
+		}
+
+		req, reqErr := http.NewRequest("GET", "/?page.limit=ten", nil)
+		require.Nil(t, reqErr)
+
+		obj := pageStruct{}
+		err := As(req, &obj)
+
+		var errs BindingErrors
+		require.ErrorAs(t, err, &errs)
+		require.Len(t, errs, 1)
+		assert.Equal(t, "Page.Limit", errs[0].Field)
+		assert.Equal(t, "page.limit", errs[0].Source)
+	})
+
+	t.Run("should reject invalid groups", func(t *testing.T) {
+		type node struct {
+			Name  string `from:"url-query=name"`
+			Child *node  `from:"group,prefix=child."`
+		}
+
+		type invalidStruct struct {
+			Count int       `from:"group"`
+			Root  node      `from:"group"`
+			inner dateRange `from:"group"`
+		}
+
+		req, reqErr := http.NewRequest("GET", "/", nil)
+		require.Nil(t, reqErr)
+
+		obj := invalidStruct{}
+		err := As(req, &obj)
+
+		var errs BindingErrors
+		require.ErrorAs(t, err, &errs)
+		require.Len(t, errs, 3)
+		assert.Equal(t, "Count", errs[0].Field)
+		assert.ErrorIs(t, errs[0], ErrInvalidGroup)
+		assert.Equal(t, "Root.Child", errs[1].Field)
+		assert.ErrorIs(t, errs[1], ErrRecursiveGroup)
+		assert.Equal(t, "inner", errs[2].Field)
+		assert.ErrorIs(t, errs[2], ErrUnexportedField)
+	})
+}
+
+func TestAsRules(t *testing.T) {
+	type rulesStruct struct {
+		Page   int      `from:"url-query=page,min=1,max=50"`
+		Ratio  *float64 `from:"url-query=ratio,oneof=0.5|1.5"`
+		Sort   string   `from:"url-query=sort,required,oneof=name|date"`
+		Tags   []string `from:"url-query=tag,minlen=1,maxlen=2,pattern=^[a-z]+$"`
+		IDs    []uint   `from:"url-query=ids,split=comma,min=1"`
+		Tenant string   `from:"header=X-Tenant,minlen=2,maxlen=4"`
+		Token  *string  `from:"header=X-Token,required"`
+		Theme  string   `from:"cookie=theme,pattern=^(light|dark)$"`
+	}
+
+	t.Run("should accept valid values", func(t *testing.T) {
+		req, reqErr := http.NewRequest("GET", "/?page=50&ratio=1.5&sort=date&tag=a&tag=bc&ids=1,2", nil)
+		require.Nil(t, reqErr)
+
+		req.Header.Set("X-Tenant", "açme")
+		req.Header.Set("X-Token", "t")
+		req.AddCookie(&http.Cookie{Name: "theme", Value: "dark"})
+
+		obj := rulesStruct{}
+		err := As(req, &obj)
+
+		require.Nil(t, err)
+		assert.Equal(t, 50, obj.Page)
+		require.NotNil(t, obj.Ratio)
+		assert.Equal(t, 1.5, *obj.Ratio)
+		assert.Equal(t, []uint{1, 2}, obj.IDs)
+	})
+
+	t.Run("should report each broken rule", func(t *testing.T) {
+		req, reqErr := http.NewRequest("GET", "/?page=0&ratio=2&sort=size&tag=a&tag=B&tag=c&ids=2,0", nil)
+		require.Nil(t, reqErr)
+
+		req.Header.Set("X-Tenant", "a")
+		req.AddCookie(&http.Cookie{Name: "theme", Value: "blue"})
+
+		obj := rulesStruct{}
+		err := As(req, &obj)
+
+		var errs BindingErrors
+		require.ErrorAs(t, err, &errs)
+		require.Len(t, errs, 8)
+		assert.ErrorIs(t, errs[0], ErrMin)
+		assert.ErrorIs(t, errs[1], ErrOneOf)
+		assert.ErrorIs(t, errs[2], ErrOneOf)
+		assert.ErrorIs(t, errs[3], ErrMaxLen)
+		assert.ErrorIs(t, errs[4], ErrMin)
+		assert.ErrorContains(t, errs[4], "element 1: value is less than the minimum (min=1)")
+		assert.Equal(t, "2,0", errs[4].Value)
+		assert.ErrorIs(t, errs[5], ErrMinLen)
+		assert.ErrorIs(t, errs[6], ErrRequired)
+		assert.Equal(t, "Token", errs[6].Field)
+		assert.ErrorIs(t, errs[7], ErrPattern)
+		assert.Equal(t, "blue", errs[7].Value)
+	})
+
+	t.Run("should check elements one by one", func(t *testing.T) {
+		req, reqErr := http.NewRequest("GET", "/?page=1&sort=name&tag=a&tag=B&ids=1", nil)
+		require.Nil(t, reqErr)
+
+		req.Header.Set("X-Tenant", "ab")
+		req.Header.Set("X-Token", "t")
+		req.AddCookie(&http.Cookie{Name: "theme", Value: "light"})
+
+		obj := rulesStruct{}
+		err := As(req, &obj)
+
+		var errs BindingErrors
+		require.ErrorAs(t, err, &errs)
+		require.Len(t, errs, 1)
+		assert.Equal(t, "Tags", errs[0].Field)
+		assert.ErrorIs(t, errs[0], ErrPattern)
+		assert.ErrorContains(t, errs[0], "element 1:")
+	})
+
+	t.Run("should reject invalid rules when building the plan", func(t *testing.T) {
+		type invalidStruct struct {
+			Min     int          `from:"url-query=min,min=one"`
+			Pattern string       `from:"url-query=pattern,pattern=[a-"`
+			Len     int          `from:"url-query=len,minlen=1"`
+			OneOf   bool         `from:"url-query=oneof,oneof=true"`
+			Numbers []int        `from:"url-query=numbers,oneof=1|two"`
+			Cookie  *http.Cookie `from:"cookie=c,maxlen=3"`
+			Body    struct{}     `from:"request-body,required"`
+			File    []byte       `from:"file=f,required"`
+		}
+
+		req, reqErr := http.NewRequest("GET", "/", nil)
+		require.Nil(t, reqErr)
+
+		obj := invalidStruct{}
+		err := As(req, &obj)
+
+		var errs BindingErrors
+		require.ErrorAs(t, err, &errs)
+		require.Len(t, errs, 8)
+		for _, e := range errs {
+			assert.ErrorIs(t, e, ErrInvalidRule)
+		}
+		assert.ErrorContains(t, errs[2], "minlen does not apply to int")
+		assert.ErrorContains(t, errs[6], "required does not apply to request-body")
+	})
+
+	t.Run("should reject values for required", func(t *testing.T) {
+		type optionalStruct struct {
+			Name string `from:"url-query=name,required=false"`
+		}
+
+		req, reqErr := http.NewRequest("GET", "/", nil)
+		require.Nil(t, reqErr)
+
+		obj := optionalStruct{}
+		err := As(req, &obj)
+
+		assert.ErrorIs(t, err, ErrInvalidParamTagKeyValue)
+		assert.NotErrorIs(t, err, ErrRequired)
+	})
+}
+
+func TestAsDefaults(t *testing.T) {
+	type defaultsStruct struct {
+		Page   int       `from:"url-query=page,default=1,min=1"`
+		Limit  *int      `from:"url-query=limit,default=20"`
+		Sort   string    `from:"url-query=sort,required,default=name"`
+		IDs    []int     `from:"url-query=ids,split=pipe,default=1|2"`
+		Since  time.Time `from:"url-query=since,layout=DateOnly,default=2024-01-02"`
+		Tenant string    `from:"header=X-Tenant,default=acme"`
+		Theme  string    `from:"cookie=theme,default=light"`
+	}
+
+	t.Run("should bind defaults when values are missing", func(t *testing.T) {
+		req, reqErr := http.NewRequest("GET", "/?page=", nil)
+		require.Nil(t, reqErr)
+
+		obj := defaultsStruct{}
+		err := As(req, &obj)
+
+		require.Nil(t, err)
+		assert.Equal(t, 1, obj.Page)
+		require.NotNil(t, obj.Limit)
+		assert.Equal(t, 20, *obj.Limit)
+		assert.Equal(t, "name", obj.Sort)
+		assert.Equal(t, []int{1, 2}, obj.IDs)
+		assert.Equal(t, time.Date(2024, time.January, 2, 0, 0, 0, 0, time.UTC), obj.Since)
+		assert.Equal(t, "acme", obj.Tenant)
+		assert.Equal(t, "light", obj.Theme)
+	})
+
+	t.Run("should prefer the request values", func(t *testing.T) {
+		req, reqErr := http.NewRequest("GET", "/?page=3&limit=5&sort=date&ids=7", nil)
+		require.Nil(t, reqErr)
+
+		req.Header.Set("X-Tenant", "other")
+		req.AddCookie(&http.Cookie{Name: "theme", Value: "dark"})
+
+		obj := defaultsStruct{}
+		err := As(req, &obj)
+
+		require.Nil(t, err)
+		assert.Equal(t, 3, obj.Page)
+		assert.Equal(t, 5, *obj.Limit)
+		assert.Equal(t, "date", obj.Sort)
+		assert.Equal(t, []int{7}, obj.IDs)
+		assert.Equal(t, "other", obj.Tenant)
+		assert.Equal(t, "dark", obj.Theme)
+	})
+
+	t.Run("should not share defaults between requests", func(t *testing.T) {
+		req, reqErr := http.NewRequest("GET", "/", nil)
+		require.Nil(t, reqErr)
+
+		first, second := defaultsStruct{}, defaultsStruct{}
+		require.Nil(t, As(req, &first))
+		require.Nil(t, As(req, &second))
+
+		first.IDs[0] = 9
+		*first.Limit = 9
+		assert.Equal(t, []int{1, 2}, second.IDs)
+		assert.Equal(t, 20, *second.Limit)
+	})
+
+	t.Run("should reject invalid defaults when building the plan", func(t *testing.T) {
+		type invalidStruct struct {
+			Page   int          `from:"url-query=page,default=first"`
+			Small  uint8        `from:"url-query=small,default=300"`
+			Min    int          `from:"url-query=min,min=1,default=0"`
+			Bare   string       `from:"url-query=bare,default"`
+			Cookie *http.Cookie `from:"cookie=c,default=x"`
+			Body   struct{}     `from:"request-body,default={}"`
+		}
+
+		req, reqErr := http.NewRequest("GET", "/?page=1", nil)
+		require.Nil(t, reqErr)
+
+		obj := invalidStruct{}
+		err := As(req, &obj)
+
+		var errs BindingErrors
+		require.ErrorAs(t, err, &errs)
+		require.Len(t, errs, 6)
+		for i, e := range errs {
+			if i == 3 {
+				assert.ErrorIs(t, e, ErrInvalidParamTagKeyValue)
+				continue
+			}
+			assert.ErrorIs(t, e, ErrInvalidDefault)
+		}
+		assert.ErrorIs(t, errs[2], ErrMin)
+		assert.Equal(t, 0, obj.Page)
+	})
+
+	t.Run("should convert defaults with the binder converters", func(t *testing.T) {
+		type level int
+
+		type levelStruct struct {
+			Level level `from:"url-query=level,default=high"`
+		}
+
+		binder := New(WithConverter(reflect.TypeOf(level(0)), func(s string, meta map[string]string) (any, error) {
+			if s == "high" {
+				return level(2), nil
+			}
+			return nil, errors.New("unknown level")
+		}))
+
+		req, reqErr := http.NewRequest("GET", "/", nil)
+		require.Nil(t, reqErr)
+
+		obj := levelStruct{}
+		err := binder.As(req, &obj)
+
+		require.Nil(t, err)
+		assert.Equal(t, level(2), obj.Level)
+	})
+}
+
+func TestAsFallbacks(t *testing.T) {
+	type tenantStruct struct {
+		Tenant string  `from:"url-param=tenant|header=X-Tenant|url-query=tenant,required,minlen=2"`
+		Theme  *string `from:"cookie=theme|url-query=theme"`
+		Limit  int     `from:"url-query=limit|header=X-Limit,default=10"`
+	}
+
+	t.Run("should bind the first source with a value", func(t *testing.T) {
+		req, reqErr := http.NewRequest("GET", "/?tenant=query&theme=light", nil)
+		require.Nil(t, reqErr)
+
+		req.Header.Set("X-Tenant", "header")
+		req.Header.Set("X-Limit", "5")
+		req.AddCookie(&http.Cookie{Name: "theme", Value: "dark"})
+
+		obj := tenantStruct{}
+		err := As(req, &obj)
+
+		require.Nil(t, err)
+		assert.Equal(t, "header", obj.Tenant)
+		require.NotNil(t, obj.Theme)
+		assert.Equal(t, "dark", *obj.Theme)
+		assert.Equal(t, 5, obj.Limit)
+
+		req.SetPathValue("tenant", "param")
+		err = As(req, &obj)
+
+		require.Nil(t, err)
+		assert.Equal(t, "param", obj.Tenant)
+	})
+
+	t.Run("should read later sources", func(t *testing.T) {
+		req, reqErr := http.NewRequest("GET", "/?tenant=query&theme=light", nil)
+		require.Nil(t, reqErr)
+
+		obj := tenantStruct{}
+		err := As(req, &obj)
+
+		require.Nil(t, err)
+		assert.Equal(t, "query", obj.Tenant)
+		require.NotNil(t, obj.Theme)
+		assert.Equal(t, "light", *obj.Theme)
+		assert.Equal(t, 10, obj.Limit)
+	})
+
+	t.Run("should report the sources tried", func(t *testing.T) {
+		req, reqErr := http.NewRequest("GET", "/?limit=x", nil)
+		require.Nil(t, reqErr)
+
+		req.Header.Set("X-Tenant", "a")
+
+		obj := tenantStruct{}
+		err := As(req, &obj)
+
+		var errs BindingErrors
+		require.ErrorAs(t, err, &errs)
+		require.Len(t, errs, 2)
+		assert.ErrorIs(t, errs[0], ErrMinLen)
+		assert.Equal(t, headerTag, errs[0].Kind)
+		assert.Equal(t, "X-Tenant", errs[0].Source)
+		assert.Equal(t, []TagSource{{Kind: urlParamTag, Source: "tenant"}, {Kind: headerTag, Source: "X-Tenant"}}, errs[0].Tried)
+		assert.Equal(t, urlQueryTag, errs[1].Kind)
+		assert.Equal(t, "x", errs[1].Value)
+
+		req, reqErr = http.NewRequest("GET", "/", nil)
+		require.Nil(t, reqErr)
+
+		err = As(req, &obj)
+
+		require.ErrorAs(t, err, &errs)
+		require.Len(t, errs, 1)
+		assert.ErrorIs(t, errs[0], ErrRequired)
+		assert.Equal(t, "", errs[0].Kind)
+		assert.Len(t, errs[0].Tried, 3)
+		assert.Equal(t, "field Tenant (url-param=tenant|header=X-Tenant|url-query=tenant): value is required", errs[0].Error())
+	})
+
+	t.Run("should prefix every source in groups", func(t *testing.T) {
+		type groupStruct struct {
+			Page struct {
+				Limit int `from:"url-query=limit|header=X-Limit"`
+			} `from:"group,prefix=page."`
+		}
+
+		req, reqErr := http.NewRequest("GET", "/", nil)
+		require.Nil(t, reqErr)
+
+		req.Header.Set("page.X-Limit", "7")
+
+		obj := groupStruct{}
+		err := As(req, &obj)
+
+		require.Nil(t, err)
+		assert.Equal(t, 7, obj.Page.Limit)
+	})
+
+	t.Run("should reject invalid fallbacks", func(t *testing.T) {
+		type invalidStruct struct {
+			Body   struct{}     `from:"request-body|url-query=body"`
+			File   []byte       `from:"url-query=file|file=file"`
+			Cookie *http.Cookie `from:"cookie=a|cookie=b"`
+		}
+
+		req, reqErr := http.NewRequest("GET", "/", nil)
+		require.Nil(t, reqErr)
+
+		obj := invalidStruct{}
+		err := As(req, &obj)
+
+		var errs BindingErrors
+		require.ErrorAs(t, err, &errs)
+		require.Len(t, errs, 3)
+		for _, e := range errs {
+			assert.ErrorIs(t, e, ErrInvalidFallback)
+		}
+	})
+}
+
+type (
+	rangeTarget struct {
+		From int `from:"url-query=from"`
+		To   int `from:"url-query=to"`
+	}
+
+	requestTarget struct {
+		Email string `from:"url-query=email"`
+	}
+
+	validatedBody struct {
+		Name  string `json:"name" from:"form=name"`
+		calls *int
+	}
+
+	bodyTarget struct {
+		Page int            `from:"url-query=page"`
+		Body *validatedBody `from:"request-body"`
+	}
+)
+
+func (r rangeTarget) Validate() error {
+	if r.From > r.To {
+		return errors.New("from is after to")
+	}
+	return nil
+}
+
+func (r *requestTarget) Validate() error {
+	return errors.New("should not be called")
+}
+
+func (r *requestTarget) ValidateRequest(req *http.Request) error {
+	if r.Email == "" && req.Header.Get("X-Phone") == "" {
+		return BindingErrors{
+			{Field: "Email", Err: ErrRequired},
+			{Field: "Phone", Err: ErrRequired},
+		}
+	}
+	return nil
+}
+
+func (b *validatedBody) Validate() error {
+	if b.calls != nil {
+		*b.calls++
+	}
+	if b.Name == "" {
+		return errors.New("name is required")
+	}
+	return nil
+}
+
+func TestAsValidators(t *testing.T) {
+	t.Run("should validate the target once bound", func(t *testing.T) {
+		req, reqErr := http.NewRequest("GET", "/?from=3&to=1", nil)
+		require.Nil(t, reqErr)
+
+		obj := rangeTarget{}
+		err := As(req, &obj)
+
+		var errs BindingErrors
+		require.ErrorAs(t, err, &errs)
+		require.Len(t, errs, 1)
+		assert.Equal(t, "", errs[0].Field)
+		assert.Equal(t, "from is after to", err.Error())
+	})
+
+	t.Run("should not validate the target when a field fails", func(t *testing.T) {
+		req, reqErr := http.NewRequest("GET", "/?from=3&to=x", nil)
+		require.Nil(t, reqErr)
+
+		obj := rangeTarget{}
+		err := As(req, &obj)
+
+		var errs BindingErrors
+		require.ErrorAs(t, err, &errs)
+		require.Len(t, errs, 1)
+		assert.Equal(t, "To", errs[0].Field)
+	})
+
+	t.Run("should prefer ValidateRequest and merge binding errors", func(t *testing.T) {
+		req, reqErr := http.NewRequest("GET", "/", nil)
+		require.Nil(t, reqErr)
+
+		obj := requestTarget{}
+		err := As(req, &obj)
+
+		var errs BindingErrors
+		require.ErrorAs(t, err, &errs)
+		require.Len(t, errs, 2)
+		assert.Equal(t, "Email", errs[0].Field)
+		assert.Equal(t, "Phone", errs[1].Field)
+
+		req.Header.Set("X-Phone", "555")
+		assert.Nil(t, As(req, &obj))
+	})
+
+	t.Run("should validate request bodies with the other fields", func(t *testing.T) {
+		req, reqErr := http.NewRequest("POST", "/?page=x", strings.NewReader(`{"name":""}`))
+		require.Nil(t, reqErr)
+
+		obj := bodyTarget{}
+		err := As(req, &obj)
+
+		var errs BindingErrors
+		require.ErrorAs(t, err, &errs)
+		require.Len(t, errs, 2)
+		assert.Equal(t, "Page", errs[0].Field)
+		assert.Equal(t, "field Body (request-body): name is required", errs[1].Error())
+	})
+
+	t.Run("should validate form bodies once", func(t *testing.T) {
+		req, reqErr := http.NewRequest("POST", "/", strings.NewReader("name=x"))
+		require.Nil(t, reqErr)
+
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+		calls := 0
+		obj := bodyTarget{Body: &validatedBody{calls: &calls}}
+		err := As(req, &obj)
+
+		require.Nil(t, err)
+		assert.Equal(t, "x", obj.Body.Name)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("should run the validator option after the method", func(t *testing.T) {
+		req, reqErr := http.NewRequest("GET", "/?from=3&to=1", nil)
+		require.Nil(t, reqErr)
+
+		var got any
+		errRange := errors.New("range too small")
+		validate := WithValidator(func(r *http.Request, obj any) error {
+			got = obj
+			return &FieldError{Field: "To", Err: errRange}
+		})
+
+		obj := rangeTarget{}
+		err := As(req, &obj, validate)
+
+		var errs BindingErrors
+		require.ErrorAs(t, err, &errs)
+		require.Len(t, errs, 2)
+		assert.Equal(t, "from is after to", errs[0].Error())
+		assert.ErrorIs(t, errs[1], errRange)
+		assert.Same(t, &obj, got)
+
+		err = As(req, &obj, validate, WithFailFast())
+
+		require.ErrorAs(t, err, &errs)
+		require.Len(t, errs, 1)
+		assert.Equal(t, "from is after to", errs[0].Error())
+	})
+}
+
+func TestAsConverters(t *testing.T) {
+	type converterStruct struct {
+		Order testOrderID   `from:"url-query=order"`
+		Level slog.Level    `from:"url-query=level"`
+		Delay time.Duration `from:"url-query=delay,unit=ms"`
+	}
+
+	durationType := reflect.TypeOf(time.Duration(0))
+	durationConv := func(s string, meta map[string]string) (any, error) {
+		if unit, ok := meta["unit"]; ok {
+			s += unit
+		}
+		return time.ParseDuration(s)
+	}
+
+	t.Run("should succeed", func(t *testing.T) {
+		req, reqErr := http.NewRequest("GET", "/hello/world?order=ord_9&level=WARN&delay=250", nil)
+		require.Nil(t, reqErr)
+
+		obj := converterStruct{}
+		err := As(req, &obj, WithConverter(durationType, durationConv))
+
+		assert.Nil(t, err)
+		assert.Equal(t, testOrderID("9"), obj.Order)
+		assert.Equal(t, slog.LevelWarn, obj.Level)
+		assert.Equal(t, 250*time.Millisecond, obj.Delay)
+	})
+
+	t.Run("should fail with the text unmarshaler error", func(t *testing.T) {
+		req, reqErr := http.NewRequest("GET", "/hello/world?order=9", nil)
+		require.Nil(t, reqErr)
+
+		obj := converterStruct{}
+		err := As(req, &obj, WithConverter(durationType, durationConv))
+
+		var fieldErr *FieldError
+		require.True(t, errors.As(err, &fieldErr))
+		assert.Equal(t, "Order", fieldErr.Field)
+	})
+
+	t.Run("should not share converters between calls", func(t *testing.T) {
+		cfg := config{}
+
+		WithConverter(durationType, durationConv)(&cfg)
+		first := cfg.Converters
+		WithConverter(reflect.TypeOf(0), durationConv)(&cfg)
+
+		assert.Len(t, first, 1)
+		assert.Len(t, cfg.Converters, 2)
+	})
+}
@@ -0,0 +1,329 @@
+package httprequest
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+type (
+	plan struct {
+		fields []field
+		errs   BindingErrors
+	}
+
+	planner struct {
+		root       reflect.Type
+		plan       plan
+		hasBody    bool
+		groups     map[reflect.Type]bool
+		converters map[reflect.Type]Converter
+	}
+
+	field struct {
+		index      []int
+		typ        reflect.Type
+		name       string
+		kind       string
+		source     string
+		conversion *conversion
+		rules      *ruleSet
+
+		// chain lists the sources of a field with fallbacks, read in order
+		// until one has a value.
+		chain []TagSource
+
+		// defaults are the params bound when the source has no value.
+		defaults []string
+
+		maxBytes int64
+	}
+
+	// conversion is how params are set into a field, resolved from its type
+	// and tag meta when the plan is built.
+	conversion struct {
+		meta   map[string]string
+		sep    string
+		layout string
+
+		// leaf is the type params are converted to: the field type, or the
+		// element type of pointers, slices and arrays.
+		leaf reflect.Type
+		conv Converter
+	}
+)
+
+func (b *Binder) cachedPlan(t reflect.Type) *plan {
+	if p, ok := b.plans.Load(t); ok {
+		return p.(*plan)
+	}
+
+	p, _ := b.plans.LoadOrStore(t, compilePlan(t, b.cfg.Converters))
+	return p.(*plan)
+}
+
+// compilePlan builds the plan of t. Defaults are converted with converters,
+// so a bad default fails here rather than on a request.
+func compilePlan(t reflect.Type, converters map[reflect.Type]Converter) *plan {
+	pl := planner{
+		root:       t,
+		groups:     map[reflect.Type]bool{t: true},
+		converters: converters,
+	}
+	pl.compile(t, nil, "")
+	return &pl.plan
+}
+
+// compile adds the fields of t, found at index below the root type, to the
+// plan. Sources read by fields inside a group are prefixed with the prefix
+// of every enclosing group.
+func (pl *planner) compile(t reflect.Type, index []int, prefix string) {
+	var groups [][]int
+
+	for _, f := range reflect.VisibleFields(t) {
+		if isPromotedFrom(f.Index, groups) {
+			continue
+		}
+
+		tag := f.Tag.Get(tagName)
+		if tag == "" || tag == "-" {
+			continue
+		}
+
+		index := append(append([]int(nil), index...), f.Index...)
+		name := fieldPath(pl.root, index)
+
+		var maxBytes int64
+
+		sources, meta, err := splitTag(tag)
+		if err != nil {
+			pl.plan.errs = append(pl.plan.errs, &FieldError{Field: name, Err: err})
+			continue
+		}
+		for i := range sources {
+			if k := sources[i].Kind; k != requestBodyTag && k != groupTag {
+				sources[i].Source = prefix + sources[i].Source
+			}
+		}
+
+		var (
+			kind   = sources[0].Kind
+			source = sources[0].Source
+			chain  []TagSource
+		)
+		if len(sources) > 1 {
+			chain = sources
+			if err := checkChain(f.Type, chain); err != nil {
+				pl.plan.errs = append(pl.plan.errs, &FieldError{Field: name, Err: err, Tried: chain})
+				continue
+			}
+		}
+
+		switch kind {
+		case urlParamTag, urlQueryTag, headerTag, cookieTag, formTag:
+		case fileTag:
+			if !isFileType(f.Type) {
+				err = ErrInvalidFileField
+			}
+		case requestBodyTag:
+			if pl.hasBody {
+				err = ErrDuplicateBody
+			} else if size, ok := meta[maxMeta]; ok {
+				maxBytes, err = parseSize(size)
+			}
+			pl.hasBody = true
+		case groupTag:
+			groups = append(groups, f.Index)
+			if err = pl.group(f.Type, index, prefix+meta[prefixMeta]); err == nil {
+				continue
+			}
+		default:
+			err = ErrUnknownSourceKind
+		}
+		if err == nil && !isSettable(pl.root, index) {
+			err = ErrUnexportedField
+		}
+
+		var (
+			conv     = newConversion(f.Type, meta, pl.converters)
+			rules    *ruleSet
+			defaults []string
+		)
+		if err == nil {
+			rules, err = compileRules(kind, f.Type, meta)
+		}
+		if err == nil {
+			defaults, err = compileDefault(kind, f.Type, conv, rules)
+		}
+
+		if err != nil {
+			pl.plan.errs = append(pl.plan.errs, &FieldError{
+				Field:  name,
+				Kind:   kind,
+				Source: source,
+				Err:    err,
+			})
+			continue
+		}
+
+		pl.plan.fields = append(pl.plan.fields, field{
+			index:      index,
+			typ:        f.Type,
+			name:       name,
+			kind:       kind,
+			source:     source,
+			conversion: conv,
+			rules:      rules,
+
+			chain:    chain,
+			defaults: defaults,
+			maxBytes: maxBytes,
+		})
+	}
+}
+
+// checkChain checks that every source in chain can be read as values into a
+// field of type t.
+func checkChain(t reflect.Type, chain []TagSource) error {
+	if t == cookieType || t == reflect.PointerTo(cookieType) {
+		return fmt.Errorf("%w: not supported by %s", ErrInvalidFallback, t)
+	}
+
+	for _, s := range chain {
+		switch s.Kind {
+		case urlParamTag, urlQueryTag, headerTag, cookieTag, formTag:
+		default:
+			return fmt.Errorf("%w: %s", ErrInvalidFallback, s.Kind)
+		}
+	}
+	return nil
+}
+
+// compileDefault returns the params of the default=<value> meta, once they
+// are known to convert to t and to pass the rules.
+func compileDefault(kind string, t reflect.Type, conv *conversion, rules *ruleSet) ([]string, error) {
+	def, ok := conv.meta[defaultMeta]
+	if !ok {
+		return nil, nil
+	}
+
+	switch {
+	case kind == fileTag || kind == requestBodyTag:
+		return nil, fmt.Errorf("%w: not supported by %s", ErrInvalidDefault, kind)
+	case t == cookieType || t == reflect.PointerTo(cookieType):
+		return nil, fmt.Errorf("%w: not supported by %s", ErrInvalidDefault, t)
+	}
+
+	params := []string{def}
+	v := reflect.New(t).Elem()
+	if err := setValues(v, params, conv); err != nil {
+		return nil, fmt.Errorf("%w %q: %w", ErrInvalidDefault, def, err)
+	}
+	if err := rules.check(v); err != nil {
+		return nil, fmt.Errorf("%w %q: %w", ErrInvalidDefault, def, err)
+	}
+	return params, nil
+}
+
+func newConversion(t reflect.Type, meta map[string]string, convs map[reflect.Type]Converter) *conversion {
+	c := &conversion{
+		meta:   meta,
+		sep:    splitSeparator(meta),
+		layout: timeLayout(meta),
+		leaf:   leafType(t, convs),
+	}
+	c.conv = convs[c.leaf]
+	return c
+}
+
+// leafType returns the type setValues converts params to for a field of
+// type t.
+func leafType(t reflect.Type, convs map[reflect.Type]Converter) reflect.Type {
+	if _, ok := convs[t]; ok || isTextUnmarshaler(t) {
+		return t
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		return leafType(t.Elem(), convs)
+	case reflect.Slice, reflect.Array:
+		return t.Elem()
+	}
+	return t
+}
+
+func (pl *planner) group(t reflect.Type, index []int, prefix string) error {
+	if !isSettable(pl.root, index) {
+		return ErrUnexportedField
+	}
+
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return ErrInvalidGroup
+	}
+	if pl.groups[t] {
+		return ErrRecursiveGroup
+	}
+
+	pl.groups[t] = true
+	pl.compile(t, index, prefix)
+	delete(pl.groups, t)
+	return nil
+}
+
+// isPromotedFrom reports whether the field at index was promoted from one of
+// the embedded groups, which bind their fields themselves.
+func isPromotedFrom(index []int, groups [][]int) bool {
+next:
+	for _, g := range groups {
+		if len(index) <= len(g) {
+			continue
+		}
+		for i, x := range g {
+			if index[i] != x {
+				continue next
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// fieldPath names the field at index using the Go selector that reaches it
+// through embedded structs, e.g. "Pagination.Limit".
+func fieldPath(t reflect.Type, index []int) string {
+	names := make([]string, len(index))
+	for i, x := range index {
+		if t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+
+		f := t.Field(x)
+		names[i] = f.Name
+		t = f.Type
+	}
+	return strings.Join(names, ".")
+}
+
+// isSettable reports whether the field at index is exported and only reached
+// through exported fields or unexported embedded struct values.
+func isSettable(t reflect.Type, index []int) bool {
+	for i, x := range index {
+		if t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+
+		f := t.Field(x)
+		last := i == len(index)-1
+		if last && !f.IsExported() {
+			return false
+		}
+		if !last && !f.IsExported() && (!f.Anonymous || f.Type.Kind() == reflect.Pointer) {
+			return false
+		}
+		t = f.Type
+	}
+	return true
+}
@@ -0,0 +1,108 @@
+package httprequest
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type benchStruct struct {
+	ID      int64     `from:"url-param=id"`
+	Page    int       `from:"url-query=page"`
+	Limit   int       `from:"url-query=limit"`
+	Sort    string    `from:"url-query=sort"`
+	Since   time.Time `from:"url-query=since,layout=DateOnly"`
+	Tenant  string    `from:"header=X-Tenant"`
+	Active  *bool     `from:"url-query=active"`
+	Ignored int       `from:"-"`
+}
+
+func TestCachedPlan(t *testing.T) {
+	t.Run("should reuse the plan for the same type", func(t *testing.T) {
+		typ := reflect.TypeOf(benchStruct{})
+		first := defaultBinder.cachedPlan(typ)
+		second := defaultBinder.cachedPlan(typ)
+
+		assert.Same(t, first, second)
+		require.Len(t, first.fields, 7)
+		assert.Equal(t, "Since", first.fields[4].name)
+		assert.Equal(t, []int{4}, first.fields[4].index)
+		assert.Equal(t, urlQueryTag, first.fields[4].kind)
+		assert.Equal(t, "since", first.fields[4].source)
+		assert.Equal(t, map[string]string{timeLayoutMeta: "DateOnly"}, first.fields[4].conversion.meta)
+		assert.Equal(t, time.DateOnly, first.fields[4].conversion.layout)
+	})
+
+	t.Run("should resolve converters", func(t *testing.T) {
+		type convStruct struct {
+			Timeouts []time.Duration `from:"url-query=timeout"`
+			Count    *int            `from:"url-query=count"`
+		}
+
+		b := New(WithConverter(reflect.TypeOf(time.Duration(0)), func(s string, _ map[string]string) (any, error) {
+			return time.ParseDuration(s)
+		}))
+		p := b.cachedPlan(reflect.TypeOf(convStruct{}))
+
+		require.Len(t, p.fields, 2)
+		assert.Equal(t, reflect.TypeOf(time.Duration(0)), p.fields[0].conversion.leaf)
+		assert.NotNil(t, p.fields[0].conversion.conv)
+		assert.Equal(t, reflect.TypeOf(0), p.fields[1].conversion.leaf)
+		assert.Nil(t, p.fields[1].conversion.conv)
+	})
+
+	t.Run("should cache tag errors", func(t *testing.T) {
+		type brokenStruct struct {
+			Name string `from:"nowhere=name"`
+		}
+
+		p := defaultBinder.cachedPlan(reflect.TypeOf(brokenStruct{}))
+
+		assert.Empty(t, p.fields)
+		require.Len(t, p.errs, 1)
+		assert.ErrorIs(t, p.errs[0], ErrUnknownSourceKind)
+		assert.Same(t, p, defaultBinder.cachedPlan(reflect.TypeOf(brokenStruct{})))
+	})
+}
+
+func newBenchRequest(b *testing.B) *http.Request {
+	req, err := http.NewRequest("GET", "/items/42?page=2&limit=50&sort=name&since=2024-01-02&active=true", nil)
+	if err != nil {
+		b.Fatal(err)
+	}
+	req.SetPathValue("id", "42")
+	req.Header.Set("X-Tenant", "acme")
+	return req
+}
+
+func BenchmarkAs(b *testing.B) {
+	req := newBenchRequest(b)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var obj benchStruct
+		if err := As(req, &obj); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkAsUncached(b *testing.B) {
+	req := newBenchRequest(b)
+	typ := reflect.TypeOf(benchStruct{})
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var obj benchStruct
+		defaultBinder.plans.Delete(typ)
+		if err := As(req, &obj); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,296 @@
+package httprequest
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+type (
+	// Rules are the validation rules set in the meta of a from tag. They are
+	// checked on the converted value: min, max and oneof apply to numbers,
+	// minlen and maxlen to the length of strings and slices, pattern and
+	// oneof to strings. Slice elements are checked one by one.
+	Rules struct {
+		Required bool
+		Min      *float64
+		Max      *float64
+		MinLen   *int
+		MaxLen   *int
+		Pattern  string
+		OneOf    []string
+	}
+
+	ruleSet struct {
+		Rules
+		pattern *regexp.Regexp
+		oneOf   []float64
+	}
+)
+
+const (
+	requiredMeta = "required"
+	minMeta      = "min"
+	minLenMeta   = "minlen"
+	maxLenMeta   = "maxlen"
+	patternMeta  = "pattern"
+	oneOfMeta    = "oneof"
+)
+
+// parseRules reads the rules in meta. The max meta of a request-body is its
+// size limit rather than a rule.
+func parseRules(kind string, meta map[string]string) (Rules, error) {
+	var (
+		r   Rules
+		err error
+	)
+
+	invalid := func(key string) error {
+		return fmt.Errorf("%w: %s=%s", ErrInvalidRule, key, meta[key])
+	}
+	bound := func(key string) (*float64, error) {
+		s, ok := meta[key]
+		if !ok {
+			return nil, nil
+		}
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return nil, invalid(key)
+		}
+		return &f, nil
+	}
+	length := func(key string) (*int, error) {
+		s, ok := meta[key]
+		if !ok {
+			return nil, nil
+		}
+		n, err := strconv.Atoi(s)
+		if err != nil || n < 0 {
+			return nil, invalid(key)
+		}
+		return &n, nil
+	}
+
+	_, r.Required = meta[requiredMeta]
+	if r.Min, err = bound(minMeta); err != nil {
+		return Rules{}, err
+	}
+	if kind != requestBodyTag {
+		if r.Max, err = bound(maxMeta); err != nil {
+			return Rules{}, err
+		}
+	}
+	if r.MinLen, err = length(minLenMeta); err != nil {
+		return Rules{}, err
+	}
+	if r.MaxLen, err = length(maxLenMeta); err != nil {
+		return Rules{}, err
+	}
+
+	if p, ok := meta[patternMeta]; ok {
+		if _, err := regexp.Compile(p); err != nil {
+			return Rules{}, invalid(patternMeta)
+		}
+		r.Pattern = p
+	}
+
+	if s, ok := meta[oneOfMeta]; ok {
+		r.OneOf = strings.Split(s, "|")
+		for _, v := range r.OneOf {
+			if v == "" {
+				return Rules{}, invalid(oneOfMeta)
+			}
+		}
+	}
+	return r, nil
+}
+
+// compileRules builds the rule set for a field of type t, or nil when its
+// tag sets no rules.
+func compileRules(kind string, t reflect.Type, meta map[string]string) (*ruleSet, error) {
+	r, err := parseRules(kind, meta)
+	if err != nil {
+		return nil, err
+	}
+	if !r.Required && r.Min == nil && r.Max == nil && r.MinLen == nil && r.MaxLen == nil &&
+		r.Pattern == "" && r.OneOf == nil {
+		return nil, nil
+	}
+
+	v := &ruleSet{Rules: r}
+	if r.Pattern != "" {
+		v.pattern = regexp.MustCompile(r.Pattern)
+	}
+
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	var (
+		elem   = t
+		sized  = t.Kind() == reflect.String
+		scalar = kind != fileTag && kind != requestBodyTag && t != cookieType
+	)
+
+	if k := t.Kind(); k == reflect.Slice || k == reflect.Array {
+		sized = true
+		for elem = t.Elem(); elem.Kind() == reflect.Pointer; {
+			elem = elem.Elem()
+		}
+	}
+
+	var (
+		number = scalar && isNumberKind(elem.Kind())
+		text   = scalar && elem.Kind() == reflect.String
+	)
+
+	invalid := func(key string) error {
+		return fmt.Errorf("%w: %s does not apply to %s", ErrInvalidRule, key, t)
+	}
+
+	switch {
+	case r.Required && (kind == fileTag || kind == requestBodyTag):
+		// Files are always required, and bodies always decoded.
+		return nil, fmt.Errorf("%w: %s does not apply to %s", ErrInvalidRule, requiredMeta, kind)
+	case r.Min != nil && !number:
+		return nil, invalid(minMeta)
+	case r.Max != nil && !number:
+		return nil, invalid(maxMeta)
+	case r.MinLen != nil && !(scalar && sized):
+		return nil, invalid(minLenMeta)
+	case r.MaxLen != nil && !(scalar && sized):
+		return nil, invalid(maxLenMeta)
+	case r.Pattern != "" && !text:
+		return nil, invalid(patternMeta)
+	case r.OneOf != nil && !number && !text:
+		return nil, invalid(oneOfMeta)
+	}
+
+	if r.OneOf != nil && number {
+		v.oneOf = make([]float64, len(r.OneOf))
+		for i, s := range r.OneOf {
+			if v.oneOf[i], err = strconv.ParseFloat(s, 64); err != nil {
+				return nil, fmt.Errorf("%w: %s=%s", ErrInvalidRule, oneOfMeta, meta[oneOfMeta])
+			}
+		}
+	}
+	return v, nil
+}
+
+func isNumberKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+func (r *ruleSet) required() bool {
+	return r != nil && r.Required
+}
+
+// check validates the bound value v against the rules.
+func (r *ruleSet) check(v reflect.Value) error {
+	if r == nil {
+		return nil
+	}
+
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		if err := r.checkLen(v.Len()); err != nil {
+			return err
+		}
+		for i := 0; i < v.Len(); i++ {
+			if err := r.checkValue(v.Index(i)); err != nil {
+				return fmt.Errorf("element %d: %w", i, err)
+			}
+		}
+		return nil
+	case reflect.String:
+		if err := r.checkLen(utf8.RuneCountInString(v.String())); err != nil {
+			return err
+		}
+	}
+	return r.checkValue(v)
+}
+
+func (r *ruleSet) checkLen(n int) error {
+	if r.MinLen != nil && n < *r.MinLen {
+		return ruleError(ErrMinLen, minLenMeta, strconv.Itoa(*r.MinLen))
+	}
+	if r.MaxLen != nil && n > *r.MaxLen {
+		return ruleError(ErrMaxLen, maxLenMeta, strconv.Itoa(*r.MaxLen))
+	}
+	return nil
+}
+
+func (r *ruleSet) checkValue(v reflect.Value) error {
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+
+	var f float64
+	switch v.Kind() {
+	case reflect.String:
+		s := v.String()
+		if r.pattern != nil && !r.pattern.MatchString(s) {
+			return ruleError(ErrPattern, patternMeta, r.Pattern)
+		}
+		for _, o := range r.OneOf {
+			if s == o {
+				return nil
+			}
+		}
+		if r.OneOf != nil {
+			return ruleError(ErrOneOf, oneOfMeta, strings.Join(r.OneOf, "|"))
+		}
+		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		f = float64(v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		f = float64(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		f = v.Float()
+	default:
+		return nil
+	}
+
+	if r.Min != nil && f < *r.Min {
+		return ruleError(ErrMin, minMeta, formatBound(*r.Min))
+	}
+	if r.Max != nil && f > *r.Max {
+		return ruleError(ErrMax, maxMeta, formatBound(*r.Max))
+	}
+	for _, o := range r.oneOf {
+		if f == o {
+			return nil
+		}
+	}
+	if r.oneOf != nil {
+		return ruleError(ErrOneOf, oneOfMeta, strings.Join(r.OneOf, "|"))
+	}
+	return nil
+}
+
+func formatBound(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
+
+func ruleError(err error, key, arg string) error {
+	return fmt.Errorf("%w (%s=%s)", err, key, arg)
+}
@@ -1,20 +1,33 @@
 package httprequest
 
 import (
+	"errors"
+	"net"
 	"reflect"
 	"strconv"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 )
 
+type testOrderID string
+
+func (id *testOrderID) UnmarshalText(text []byte) error {
+	if !strings.HasPrefix(string(text), "ord_") {
+		return errors.New("invalid order id")
+	}
+	*id = testOrderID(strings.TrimPrefix(string(text), "ord_"))
+	return nil
+}
+
 func TestSetValueBool(t *testing.T) {
 	t.Run("setValue bool should fail", func(t *testing.T) {
 		flag := true
 		param := "error"
 		_, expected := strconv.ParseBool(param)
-		err := setValue(reflect.ValueOf(&flag).Elem(), param, nil)
+		err := setValue(reflect.ValueOf(&flag).Elem(), param, "")
 
 		assert.Equal(t, expected, err)
 	})
@@ -22,7 +35,7 @@ func TestSetValueBool(t *testing.T) {
 	t.Run("setValue bool succeed with value false", func(t *testing.T) {
 		flag := true
 		param := "false"
-		err := setValue(reflect.ValueOf(&flag).Elem(), param, nil)
+		err := setValue(reflect.ValueOf(&flag).Elem(), param, "")
 
 		assert.Nil(t, err)
 		assert.False(t, flag)
@@ -31,7 +44,7 @@ func TestSetValueBool(t *testing.T) {
 	t.Run("setValue bool succeed with value true", func(t *testing.T) {
 		flag := false
 		param := "true"
-		err := setValue(reflect.ValueOf(&flag).Elem(), param, nil)
+		err := setValue(reflect.ValueOf(&flag).Elem(), param, "")
 
 		assert.Nil(t, err)
 		assert.True(t, flag)
@@ -46,7 +59,7 @@ func TestSetValueBool(t *testing.T) {
 		v := reflect.ValueOf(&st).Elem()
 		f := v.FieldByName("Flag")
 
-		err := setValue(f, "true", nil)
+		err := setValue(f, "true", "")
 
 		assert.Nil(t, err)
 		assert.True(t, st.Flag)
@@ -58,7 +71,7 @@ func TestSetValueInt(t *testing.T) {
 		i := 0
 		param := "error"
 		_, expected := strconv.ParseInt(param, 10, 64)
-		err := setValue(reflect.ValueOf(&i).Elem(), param, nil)
+		err := setValue(reflect.ValueOf(&i).Elem(), param, "")
 
 		assert.Equal(t, expected, err)
 	})
@@ -67,7 +80,7 @@ func TestSetValueInt(t *testing.T) {
 		i := 0
 		expected := 10
 		param := strconv.FormatInt(int64(expected), 10)
-		err := setValue(reflect.ValueOf(&i).Elem(), param, nil)
+		err := setValue(reflect.ValueOf(&i).Elem(), param, "")
 
 		assert.Nil(t, err)
 		assert.Equal(t, expected, i)
@@ -83,7 +96,7 @@ func TestSetValueInt(t *testing.T) {
 		f := v.FieldByName("Int")
 		expected := 10
 		param := strconv.FormatInt(int64(expected), 10)
-		err := setValue(f, param, nil)
+		err := setValue(f, param, "")
 
 		assert.Nil(t, err)
 		assert.Equal(t, expected, st.Int)
@@ -95,7 +108,7 @@ func TestSetValueInt8(t *testing.T) {
 		i := int8(0)
 		param := "error"
 		_, expected := strconv.ParseInt(param, 10, 8)
-		err := setValue(reflect.ValueOf(&i).Elem(), param, nil)
+		err := setValue(reflect.ValueOf(&i).Elem(), param, "")
 
 		assert.Equal(t, expected, err)
 	})
@@ -104,7 +117,7 @@ func TestSetValueInt8(t *testing.T) {
 		i := int8(0)
 		expected := int8(10)
 		param := strconv.FormatInt(int64(expected), 10)
-		err := setValue(reflect.ValueOf(&i).Elem(), param, nil)
+		err := setValue(reflect.ValueOf(&i).Elem(), param, "")
 
 		assert.Nil(t, err)
 		assert.Equal(t, expected, i)
@@ -120,7 +133,7 @@ func TestSetValueInt8(t *testing.T) {
 		f := v.FieldByName("Int")
 		expected := int8(10)
 		param := strconv.FormatInt(int64(expected), 10)
-		err := setValue(f, param, nil)
+		err := setValue(f, param, "")
 
 		assert.Nil(t, err)
 		assert.Equal(t, expected, st.Int)
@@ -132,7 +145,7 @@ func TestSetValueInt16(t *testing.T) {
 		i := int16(0)
 		param := "error"
 		_, expected := strconv.ParseInt(param, 10, 8)
-		err := setValue(reflect.ValueOf(&i).Elem(), param, nil)
+		err := setValue(reflect.ValueOf(&i).Elem(), param, "")
 
 		assert.Equal(t, expected, err)
 	})
@@ -141,7 +154,7 @@ func TestSetValueInt16(t *testing.T) {
 		i := int16(0)
 		expected := int16(10)
 		param := strconv.FormatInt(int64(expected), 10)
-		err := setValue(reflect.ValueOf(&i).Elem(), param, nil)
+		err := setValue(reflect.ValueOf(&i).Elem(), param, "")
 
 		assert.Nil(t, err)
 		assert.Equal(t, expected, i)
@@ -157,7 +170,7 @@ func TestSetValueInt16(t *testing.T) {
 		f := v.FieldByName("Int")
 		expected := int16(10)
 		param := strconv.FormatInt(int64(expected), 10)
-		err := setValue(f, param, nil)
+		err := setValue(f, param, "")
 
 		assert.Nil(t, err)
 		assert.Equal(t, expected, st.Int)
@@ -169,7 +182,7 @@ func TestSetValueInt32(t *testing.T) {
 		i := int32(0)
 		param := "error"
 		_, expected := strconv.ParseInt(param, 10, 8)
-		err := setValue(reflect.ValueOf(&i).Elem(), param, nil)
+		err := setValue(reflect.ValueOf(&i).Elem(), param, "")
 
 		assert.Equal(t, expected, err)
 	})
@@ -178,7 +191,7 @@ func TestSetValueInt32(t *testing.T) {
 		i := int32(0)
 		expected := int32(10)
 		param := strconv.FormatInt(int64(expected), 10)
-		err := setValue(reflect.ValueOf(&i).Elem(), param, nil)
+		err := setValue(reflect.ValueOf(&i).Elem(), param, "")
 
 		assert.Nil(t, err)
 		assert.Equal(t, expected, i)
@@ -194,7 +207,7 @@ func TestSetValueInt32(t *testing.T) {
 		f := v.FieldByName("Int")
 		expected := int32(10)
 		param := strconv.FormatInt(int64(expected), 10)
-		err := setValue(f, param, nil)
+		err := setValue(f, param, "")
 
 		assert.Nil(t, err)
 		assert.Equal(t, expected, st.Int)
@@ -206,7 +219,7 @@ func TestSetValueInt64(t *testing.T) {
 		i := int64(0)
 		param := "error"
 		_, expected := strconv.ParseInt(param, 10, 8)
-		err := setValue(reflect.ValueOf(&i).Elem(), param, nil)
+		err := setValue(reflect.ValueOf(&i).Elem(), param, "")
 
 		assert.Equal(t, expected, err)
 	})
@@ -215,7 +228,7 @@ func TestSetValueInt64(t *testing.T) {
 		i := int64(0)
 		expected := int64(10)
 		param := strconv.FormatInt(int64(expected), 10)
-		err := setValue(reflect.ValueOf(&i).Elem(), param, nil)
+		err := setValue(reflect.ValueOf(&i).Elem(), param, "")
 
 		assert.Nil(t, err)
 		assert.Equal(t, expected, i)
@@ -231,7 +244,7 @@ func TestSetValueInt64(t *testing.T) {
 		f := v.FieldByName("Int")
 		expected := int64(10)
 		param := strconv.FormatInt(int64(expected), 10)
-		err := setValue(f, param, nil)
+		err := setValue(f, param, "")
 
 		assert.Nil(t, err)
 		assert.Equal(t, expected, st.Int)
@@ -243,7 +256,7 @@ func TestSetValueUint(t *testing.T) {
 		i := uint(0)
 		param := "error"
 		_, expected := strconv.ParseUint(param, 10, 64)
-		err := setValue(reflect.ValueOf(&i).Elem(), param, nil)
+		err := setValue(reflect.ValueOf(&i).Elem(), param, "")
 
 		assert.Equal(t, expected, err)
 	})
@@ -252,7 +265,7 @@ func TestSetValueUint(t *testing.T) {
 		i := uint(0)
 		expected := uint(10)
 		param := strconv.FormatInt(int64(expected), 10)
-		err := setValue(reflect.ValueOf(&i).Elem(), param, nil)
+		err := setValue(reflect.ValueOf(&i).Elem(), param, "")
 
 		assert.Nil(t, err)
 		assert.Equal(t, expected, i)
@@ -268,7 +281,7 @@ func TestSetValueUint(t *testing.T) {
 		f := v.FieldByName("Int")
 		expected := uint(10)
 		param := strconv.FormatUint(uint64(expected), 10)
-		err := setValue(f, param, nil)
+		err := setValue(f, param, "")
 
 		assert.Nil(t, err)
 		assert.Equal(t, expected, st.Int)
@@ -280,7 +293,7 @@ func TestSetValueUint8(t *testing.T) {
 		i := uint8(0)
 		param := "error"
 		_, expected := strconv.ParseUint(param, 10, 8)
-		err := setValue(reflect.ValueOf(&i).Elem(), param, nil)
+		err := setValue(reflect.ValueOf(&i).Elem(), param, "")
 
 		assert.Equal(t, expected, err)
 	})
@@ -289,7 +302,7 @@ func TestSetValueUint8(t *testing.T) {
 		i := uint8(0)
 		expected := uint8(10)
 		param := strconv.FormatUint(uint64(expected), 10)
-		err := setValue(reflect.ValueOf(&i).Elem(), param, nil)
+		err := setValue(reflect.ValueOf(&i).Elem(), param, "")
 
 		assert.Nil(t, err)
 		assert.Equal(t, expected, i)
@@ -305,7 +318,7 @@ func TestSetValueUint8(t *testing.T) {
 		f := v.FieldByName("Int")
 		expected := uint8(10)
 		param := strconv.FormatUint(uint64(expected), 10)
-		err := setValue(f, param, nil)
+		err := setValue(f, param, "")
 
 		assert.Nil(t, err)
 		assert.Equal(t, expected, st.Int)
@@ -317,7 +330,7 @@ func TestSetValueUint16(t *testing.T) {
 		i := uint16(0)
 		param := "error"
 		_, expected := strconv.ParseUint(param, 10, 8)
-		err := setValue(reflect.ValueOf(&i).Elem(), param, nil)
+		err := setValue(reflect.ValueOf(&i).Elem(), param, "")
 
 		assert.Equal(t, expected, err)
 	})
@@ -326,7 +339,7 @@ func TestSetValueUint16(t *testing.T) {
 		i := uint16(0)
 		expected := uint16(10)
 		param := strconv.FormatUint(uint64(expected), 10)
-		err := setValue(reflect.ValueOf(&i).Elem(), param, nil)
+		err := setValue(reflect.ValueOf(&i).Elem(), param, "")
 
 		assert.Nil(t, err)
 		assert.Equal(t, expected, i)
@@ -342,7 +355,7 @@ func TestSetValueUint16(t *testing.T) {
 		f := v.FieldByName("Int")
 		expected := uint16(10)
 		param := strconv.FormatUint(uint64(expected), 10)
-		err := setValue(f, param, nil)
+		err := setValue(f, param, "")
 
 		assert.Nil(t, err)
 		assert.Equal(t, expected, st.Int)
@@ -354,7 +367,7 @@ func TestSetValueUint32(t *testing.T) {
 		i := uint32(0)
 		param := "error"
 		_, expected := strconv.ParseUint(param, 10, 8)
-		err := setValue(reflect.ValueOf(&i).Elem(), param, nil)
+		err := setValue(reflect.ValueOf(&i).Elem(), param, "")
 
 		assert.Equal(t, expected, err)
 	})
@@ -363,7 +376,7 @@ func TestSetValueUint32(t *testing.T) {
 		i := uint32(0)
 		expected := uint32(10)
 		param := strconv.FormatUint(uint64(expected), 10)
-		err := setValue(reflect.ValueOf(&i).Elem(), param, nil)
+		err := setValue(reflect.ValueOf(&i).Elem(), param, "")
 
 		assert.Nil(t, err)
 		assert.Equal(t, expected, i)
@@ -379,7 +392,7 @@ func TestSetValueUint32(t *testing.T) {
 		f := v.FieldByName("Int")
 		expected := uint32(10)
 		param := strconv.FormatUint(uint64(expected), 10)
-		err := setValue(f, param, nil)
+		err := setValue(f, param, "")
 
 		assert.Nil(t, err)
 		assert.Equal(t, expected, st.Int)
@@ -391,7 +404,7 @@ func TestSetValueUint64(t *testing.T) {
 		i := uint64(0)
 		param := "error"
 		_, expected := strconv.ParseUint(param, 10, 8)
-		err := setValue(reflect.ValueOf(&i).Elem(), param, nil)
+		err := setValue(reflect.ValueOf(&i).Elem(), param, "")
 
 		assert.Equal(t, expected, err)
 	})
@@ -400,7 +413,7 @@ func TestSetValueUint64(t *testing.T) {
 		i := uint64(0)
 		expected := uint64(10)
 		param := strconv.FormatUint(uint64(expected), 10)
-		err := setValue(reflect.ValueOf(&i).Elem(), param, nil)
+		err := setValue(reflect.ValueOf(&i).Elem(), param, "")
 
 		assert.Nil(t, err)
 		assert.Equal(t, expected, i)
@@ -416,7 +429,7 @@ func TestSetValueUint64(t *testing.T) {
 		f := v.FieldByName("Int")
 		expected := uint64(10)
 		param := strconv.FormatUint(uint64(expected), 10)
-		err := setValue(f, param, nil)
+		err := setValue(f, param, "")
 
 		assert.Nil(t, err)
 		assert.Equal(t, expected, st.Int)
@@ -428,7 +441,7 @@ func TestSetValueFloat32(t *testing.T) {
 		f := float32(0)
 		param := "error"
 		_, expected := strconv.ParseFloat(param, 32)
-		err := setValue(reflect.ValueOf(&f).Elem(), param, nil)
+		err := setValue(reflect.ValueOf(&f).Elem(), param, "")
 
 		assert.Equal(t, expected, err)
 	})
@@ -437,7 +450,7 @@ func TestSetValueFloat32(t *testing.T) {
 		f := float32(0)
 		expected := float32(123.4567)
 		param := strconv.FormatFloat(float64(expected), 'f', 4, 32)
-		err := setValue(reflect.ValueOf(&f).Elem(), param, nil)
+		err := setValue(reflect.ValueOf(&f).Elem(), param, "")
 
 		assert.Nil(t, err)
 		assert.Equal(t, expected, f)
@@ -453,7 +466,7 @@ func TestSetValueFloat32(t *testing.T) {
 		f := v.FieldByName("Float")
 		expected := float32(123.4567)
 		param := strconv.FormatFloat(float64(expected), 'f', 4, 32)
-		err := setValue(f, param, nil)
+		err := setValue(f, param, "")
 
 		assert.Nil(t, err)
 		assert.Equal(t, expected, st.Float)
@@ -465,7 +478,7 @@ func TestSetValueFloat64(t *testing.T) {
 		f := 0.0
 		param := "error"
 		_, expected := strconv.ParseFloat(param, 64)
-		err := setValue(reflect.ValueOf(&f).Elem(), param, nil)
+		err := setValue(reflect.ValueOf(&f).Elem(), param, "")
 
 		assert.Equal(t, expected, err)
 	})
@@ -474,7 +487,7 @@ func TestSetValueFloat64(t *testing.T) {
 		f := 0.0
 		expected := 123.456790123
 		param := strconv.FormatFloat(expected, 'f', 9, 64)
-		err := setValue(reflect.ValueOf(&f).Elem(), param, nil)
+		err := setValue(reflect.ValueOf(&f).Elem(), param, "")
 
 		assert.Nil(t, err)
 		assert.Equal(t, expected, f)
@@ -490,7 +503,7 @@ func TestSetValueFloat64(t *testing.T) {
 		f := v.FieldByName("Float")
 		expected := 123.456790123
 		param := strconv.FormatFloat(expected, 'f', 9, 64)
-		err := setValue(f, param, nil)
+		err := setValue(f, param, "")
 
 		assert.Nil(t, err)
 		assert.Equal(t, expected, st.Float)
@@ -502,7 +515,7 @@ func TestSetValueTime(t *testing.T) {
 		var tm time.Time
 		param := "error"
 		_, expected := time.Parse(time.RFC3339, param)
-		err := setValue(reflect.ValueOf(&tm).Elem(), param, nil)
+		err := setValue(reflect.ValueOf(&tm).Elem(), param, time.RFC3339)
 
 		assert.Equal(t, expected, err)
 	})
@@ -511,7 +524,7 @@ func TestSetValueTime(t *testing.T) {
 		var tm time.Time
 		expected := time.Now()
 		param := expected.Format(time.RFC3339)
-		err := setValue(reflect.ValueOf(&tm).Elem(), param, nil)
+		err := setValue(reflect.ValueOf(&tm).Elem(), param, time.RFC3339)
 
 		assert.Nil(t, err)
 		assert.Equal(t, expected.Format(time.RFC3339), tm.Format(time.RFC3339))
@@ -547,7 +560,7 @@ func TestSetValueTime(t *testing.T) {
 
 				layout := timeLayout(meta)
 				param := expected.Format(layout)
-				err := setValue(reflect.ValueOf(&tm).Elem(), param, meta)
+				err := setValue(reflect.ValueOf(&tm).Elem(), param, layout)
 
 				assert.Nil(t, err)
 				assert.Equal(t, expected.Format(layout), tm.Format(layout))
@@ -565,9 +578,180 @@ func TestSetValueTime(t *testing.T) {
 		f := v.FieldByName("Time")
 		expected := time.Now()
 		param := expected.Format(time.RFC3339)
-		err := setValue(f, param, nil)
+		err := setValue(f, param, time.RFC3339)
 
 		assert.Nil(t, err)
 		assert.Equal(t, expected.Format(time.RFC3339), st.Time.Format(time.RFC3339))
 	})
 }
+
+func TestSetValues(t *testing.T) {
+	t.Run("setValues slice succeed", func(t *testing.T) {
+		var ints []int
+		err := setValues(reflect.ValueOf(&ints).Elem(), []string{"1", "2", "3"}, newConversion(reflect.TypeOf(ints), nil, nil))
+
+		assert.Nil(t, err)
+		assert.Equal(t, []int{1, 2, 3}, ints)
+	})
+
+	t.Run("setValues slice with split meta succeed", func(t *testing.T) {
+		metas := map[string]string{
+			"comma":     "1,2",
+			"semicolon": "1;2",
+			"space":     "1 2",
+			"pipe":      "1|2",
+			":":         "1:2",
+		}
+
+		for sep, param := range metas {
+			t.Run("split: "+sep, func(t *testing.T) {
+				var ints []int64
+				err := setValues(reflect.ValueOf(&ints).Elem(), []string{param, "3"}, newConversion(reflect.TypeOf(ints), map[string]string{splitMeta: sep}, nil))
+
+				assert.Nil(t, err)
+				assert.Equal(t, []int64{1, 2, 3}, ints)
+			})
+		}
+	})
+
+	t.Run("setValues time slice succeed", func(t *testing.T) {
+		var times []time.Time
+		meta := map[string]string{timeLayoutMeta: "DateOnly"}
+		err := setValues(reflect.ValueOf(&times).Elem(), []string{"2024-01-02", "2024-03-04"}, newConversion(reflect.TypeOf(times), meta, nil))
+
+		assert.Nil(t, err)
+		assert.Len(t, times, 2)
+		assert.Equal(t, time.March, times[1].Month())
+	})
+
+	t.Run("setValues slice should fail", func(t *testing.T) {
+		ints := []int{9}
+		err := setValues(reflect.ValueOf(&ints).Elem(), []string{"1", "x"}, newConversion(reflect.TypeOf(ints), nil, nil))
+
+		assert.True(t, errors.Is(err, strconv.ErrSyntax))
+		assert.Equal(t, []int{9}, ints)
+	})
+
+	t.Run("setValues array succeed", func(t *testing.T) {
+		var floats [2]float64
+		err := setValues(reflect.ValueOf(&floats).Elem(), []string{"1.5", "2.5"}, newConversion(reflect.TypeOf(floats), nil, nil))
+
+		assert.Nil(t, err)
+		assert.Equal(t, [2]float64{1.5, 2.5}, floats)
+	})
+
+	t.Run("setValues array should fail with the wrong length", func(t *testing.T) {
+		var floats [2]float64
+		err := setValues(reflect.ValueOf(&floats).Elem(), []string{"1.5", "2.5", "3.5"}, newConversion(reflect.TypeOf(floats), nil, nil))
+
+		assert.True(t, errors.Is(err, ErrArrayLength))
+	})
+
+	t.Run("setValues pointer succeed", func(t *testing.T) {
+		var flag *bool
+		err := setValues(reflect.ValueOf(&flag).Elem(), []string{"false"}, newConversion(reflect.TypeOf(flag), nil, nil))
+
+		assert.Nil(t, err)
+		if assert.NotNil(t, flag) {
+			assert.False(t, *flag)
+		}
+	})
+
+	t.Run("setValues pointer should fail and stay nil", func(t *testing.T) {
+		var i *int
+		err := setValues(reflect.ValueOf(&i).Elem(), []string{"x"}, newConversion(reflect.TypeOf(i), nil, nil))
+
+		assert.True(t, errors.Is(err, strconv.ErrSyntax))
+		assert.Nil(t, i)
+	})
+
+	t.Run("setValues scalar uses the first value", func(t *testing.T) {
+		var str string
+		err := setValues(reflect.ValueOf(&str).Elem(), []string{"a", "b"}, newConversion(reflect.TypeOf(str), nil, nil))
+
+		assert.Nil(t, err)
+		assert.Equal(t, "a", str)
+	})
+}
+
+func TestSetValueTextUnmarshaler(t *testing.T) {
+	t.Run("setValue text unmarshaler succeed", func(t *testing.T) {
+		var id testOrderID
+		err := setValue(reflect.ValueOf(&id).Elem(), "ord_42", "")
+
+		assert.Nil(t, err)
+		assert.Equal(t, testOrderID("42"), id)
+	})
+
+	t.Run("setValue text unmarshaler should fail", func(t *testing.T) {
+		var id testOrderID
+		err := setValue(reflect.ValueOf(&id).Elem(), "42", "")
+
+		assert.NotNil(t, err)
+	})
+
+	t.Run("setValues text unmarshaler slice succeed", func(t *testing.T) {
+		var ids []testOrderID
+		err := setValues(reflect.ValueOf(&ids).Elem(), []string{"ord_1", "ord_2"}, newConversion(reflect.TypeOf(ids), nil, nil))
+
+		assert.Nil(t, err)
+		assert.Equal(t, []testOrderID{"1", "2"}, ids)
+	})
+
+	t.Run("setValues slice text unmarshaler succeed", func(t *testing.T) {
+		var ip net.IP
+		err := setValues(reflect.ValueOf(&ip).Elem(), []string{"10.0.0.1"}, newConversion(reflect.TypeOf(ip), nil, nil))
+
+		assert.Nil(t, err)
+		assert.Equal(t, "10.0.0.1", ip.String())
+	})
+}
+
+func TestSetConverted(t *testing.T) {
+	type money struct {
+		Cents int64
+		Unit  string
+	}
+
+	convs := map[reflect.Type]Converter{
+		reflect.TypeOf(money{}): func(s string, meta map[string]string) (any, error) {
+			cents, err := strconv.ParseInt(s, 10, 64)
+			return money{Cents: cents, Unit: meta["unit"]}, err
+		},
+		reflect.TypeOf(0): func(s string, meta map[string]string) (any, error) {
+			return "not an int", nil
+		},
+	}
+
+	t.Run("setConverted succeed", func(t *testing.T) {
+		var m money
+		err := setConverted(reflect.ValueOf(&m).Elem(), convs[reflect.TypeOf(m)], "150", map[string]string{"unit": "BRL"})
+
+		assert.Nil(t, err)
+		assert.Equal(t, money{Cents: 150, Unit: "BRL"}, m)
+	})
+
+	t.Run("setConverted should fail with the converter error", func(t *testing.T) {
+		var m money
+		err := setConverted(reflect.ValueOf(&m).Elem(), convs[reflect.TypeOf(m)], "x", nil)
+
+		assert.True(t, errors.Is(err, strconv.ErrSyntax))
+	})
+
+	t.Run("setConverted should fail with the wrong type", func(t *testing.T) {
+		var i int
+		err := setConverted(reflect.ValueOf(&i).Elem(), convs[reflect.TypeOf(i)], "1", nil)
+
+		assert.True(t, errors.Is(err, ErrConverterType))
+	})
+
+	t.Run("setValues pointer and slice use the element converter", func(t *testing.T) {
+		var p *money
+		var ms []money
+
+		assert.Nil(t, setValues(reflect.ValueOf(&p).Elem(), []string{"5"}, newConversion(reflect.TypeOf(p), nil, convs)))
+		assert.Nil(t, setValues(reflect.ValueOf(&ms).Elem(), []string{"1", "2"}, newConversion(reflect.TypeOf(ms), nil, convs)))
+		assert.Equal(t, &money{Cents: 5}, p)
+		assert.Equal(t, []money{{Cents: 1}, {Cents: 2}}, ms)
+	})
+}
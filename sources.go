@@ -0,0 +1,138 @@
+package httprequest
+
+import (
+	"mime/multipart"
+	"net/http"
+	"reflect"
+)
+
+type (
+	// Sources exposes the request lookups used by As to the binders
+	// generated by httprequest-gen.
+	Sources struct {
+		cfg config
+		b   binding
+	}
+
+	// Tag is a parsed from struct tag. Fallbacks are the sources listed after
+	// the first one, separated by "|", read in order when it has no value.
+	Tag struct {
+		Kind      string
+		Source    string
+		Meta      map[string]string
+		Fallbacks []TagSource
+	}
+
+	// TagSource is one kind=source pair of a from struct tag.
+	TagSource struct {
+		Kind   string
+		Source string
+	}
+)
+
+// NewSources is Binder.Sources for the Binder used by As and Bind.
+func NewSources(req *http.Request, opts ...Option) *Sources {
+	return defaultBinder.Sources(req, opts...)
+}
+
+func (s *Sources) FailFast() bool {
+	return s.cfg.FailFast
+}
+
+func (s *Sources) Values(kind, source string) ([]string, error) {
+	return s.b.lookup(kind, source)
+}
+
+func (s *Sources) Cookie(name string) (*http.Cookie, error) {
+	return s.cfg.Cookie(s.b.req, name)
+}
+
+func (s *Sources) Files(name string) ([]*multipart.FileHeader, error) {
+	return s.b.files(name)
+}
+
+func (s *Sources) Decode(v any) error {
+	return s.b.decode(v, 0)
+}
+
+// First returns the values of the first source in chain holding one, along
+// with that source and the sources looked up. The source is zero when none
+// has a value.
+func (s *Sources) First(chain []TagSource, pointer bool) (TagSource, []string, []TagSource, error) {
+	return s.b.lookupFirst(chain, pointer)
+}
+
+// ValidateBody runs the Validate or ValidateRequest method of the bound body
+// v, a pointer, adding its errors to errs.
+func (s *Sources) ValidateBody(errs BindingErrors, field string, v any) BindingErrors {
+	return s.b.validateBody(errs, field, v)
+}
+
+// ValidateTarget runs the Validate or ValidateRequest method of obj and the
+// validator set with WithValidator, adding their errors to errs.
+func (s *Sources) ValidateTarget(errs BindingErrors, obj any) BindingErrors {
+	return s.b.validateTarget(errs, obj)
+}
+
+// DecodeMax is Decode for fields with a max=<size> tag meta.
+func (s *Sources) DecodeMax(v any, maxBytes int64) error {
+	return s.b.decode(v, maxBytes)
+}
+
+// Convert runs the converter registered for the type dst points to, if any.
+func (s *Sources) Convert(dst any, param string, meta map[string]string) (bool, error) {
+	if len(s.cfg.Converters) < 1 {
+		return false, nil
+	}
+
+	v := reflect.ValueOf(dst).Elem()
+	conv, ok := s.cfg.Converters[v.Type()]
+	if !ok {
+		return false, nil
+	}
+	return true, setConverted(v, conv, param, meta)
+}
+
+func ParseTag(tag string) (Tag, error) {
+	sources, meta, err := splitTag(tag)
+	if err != nil {
+		return Tag{}, err
+	}
+
+	t := Tag{Kind: sources[0].Kind, Source: sources[0].Source, Meta: meta}
+	if len(sources) > 1 {
+		t.Fallbacks = sources[1:]
+	}
+	return t, nil
+}
+
+func (s TagSource) String() string {
+	return s.Kind + "=" + s.Source
+}
+
+func (t Tag) TimeLayout() string {
+	return timeLayout(t.Meta)
+}
+
+func (t Tag) Separator() string {
+	return splitSeparator(t.Meta)
+}
+
+func (t Tag) Rules() (Rules, error) {
+	return parseRules(t.Kind, t.Meta)
+}
+
+// Default returns the value of a default=<value> meta, bound when the
+// source has none.
+func (t Tag) Default() (string, bool) {
+	def, ok := t.Meta[defaultMeta]
+	return def, ok
+}
+
+// MaxBytes returns the body size limit set by a max=<size> meta, or zero.
+func (t Tag) MaxBytes() (int64, error) {
+	if size, ok := t.Meta[maxMeta]; ok && t.Kind == requestBodyTag {
+		return parseSize(size)
+	}
+	return 0, nil
+}
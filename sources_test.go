@@ -0,0 +1,164 @@
+package httprequest
+
+import (
+	"net/http"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTag(t *testing.T) {
+	t.Run("should succeed", func(t *testing.T) {
+		tag, err := ParseTag("url-query=since,layout=DateOnly,split=comma")
+
+		require.Nil(t, err)
+		assert.Equal(t, urlQueryTag, tag.Kind)
+		assert.Equal(t, "since", tag.Source)
+		assert.Equal(t, time.DateOnly, tag.TimeLayout())
+		assert.Equal(t, ",", tag.Separator())
+	})
+
+	t.Run("should parse body size limits", func(t *testing.T) {
+		tag, err := ParseTag("request-body,max=2KB")
+
+		require.Nil(t, err)
+		n, err := tag.MaxBytes()
+		assert.Nil(t, err)
+		assert.Equal(t, int64(2048), n)
+
+		tag, err = ParseTag("request-body,max=big")
+
+		require.Nil(t, err)
+		_, err = tag.MaxBytes()
+		assert.ErrorIs(t, err, ErrInvalidSize)
+	})
+
+	t.Run("should parse rules", func(t *testing.T) {
+		tag, err := ParseTag("url-query=sort,required,minlen=2,oneof=name|date")
+
+		require.Nil(t, err)
+		r, err := tag.Rules()
+		assert.Nil(t, err)
+		assert.True(t, r.Required)
+		require.NotNil(t, r.MinLen)
+		assert.Equal(t, 2, *r.MinLen)
+		assert.Nil(t, r.Max)
+		assert.Equal(t, []string{"name", "date"}, r.OneOf)
+
+		tag, err = ParseTag("request-body,max=1MB")
+
+		require.Nil(t, err)
+		r, err = tag.Rules()
+		assert.Nil(t, err)
+		assert.Nil(t, r.Max)
+
+		tag, err = ParseTag("url-query=page,min=first")
+
+		require.Nil(t, err)
+		_, err = tag.Rules()
+		assert.ErrorIs(t, err, ErrInvalidRule)
+	})
+
+	t.Run("should parse defaults", func(t *testing.T) {
+		tag, err := ParseTag("url-query=page,default=1")
+
+		require.Nil(t, err)
+		def, ok := tag.Default()
+		assert.True(t, ok)
+		assert.Equal(t, "1", def)
+
+		tag, err = ParseTag("url-query=page")
+
+		require.Nil(t, err)
+		_, ok = tag.Default()
+		assert.False(t, ok)
+	})
+
+	t.Run("should parse fallbacks", func(t *testing.T) {
+		tag, err := ParseTag("url-param=tenant|header=X-Tenant")
+
+		require.Nil(t, err)
+		assert.Equal(t, urlParamTag, tag.Kind)
+		assert.Equal(t, "tenant", tag.Source)
+		assert.Equal(t, []TagSource{{Kind: headerTag, Source: "X-Tenant"}}, tag.Fallbacks)
+
+		tag, err = ParseTag("url-param=tenant")
+
+		require.Nil(t, err)
+		assert.Nil(t, tag.Fallbacks)
+	})
+
+	t.Run("should fail", func(t *testing.T) {
+		_, err := ParseTag("url-query")
+
+		assert.Equal(t, ErrInvalidParamTagKeyValue, err)
+	})
+}
+
+func TestSources(t *testing.T) {
+	req, reqErr := http.NewRequest("GET", "/hello/world?tag=a&tag=b", nil)
+	require.Nil(t, reqErr)
+
+	req.Header.Set("X-Tenant", "acme")
+	req.AddCookie(&http.Cookie{Name: "session", Value: "s3cr3t"})
+
+	t.Run("should look up values", func(t *testing.T) {
+		s := NewSources(req, WithFailFast())
+
+		query, err := s.Values(urlQueryTag, "tag")
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"a", "b"}, query)
+
+		header, err := s.Values(headerTag, "x-tenant")
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"acme"}, header)
+
+		c, err := s.Cookie("session")
+		assert.Nil(t, err)
+		assert.Equal(t, "s3cr3t", c.Value)
+
+		_, err = s.Values("nowhere", "x")
+		assert.ErrorIs(t, err, ErrUnknownSourceKind)
+		assert.True(t, s.FailFast())
+	})
+
+	t.Run("should only convert registered types", func(t *testing.T) {
+		s := NewSources(req, WithConverter(reflect.TypeOf(0), func(s string, _ map[string]string) (any, error) {
+			return strconv.Atoi(s)
+		}))
+
+		var (
+			i   int
+			str string
+		)
+
+		ok, err := s.Convert(&i, "12", nil)
+		assert.True(t, ok)
+		assert.Nil(t, err)
+		assert.Equal(t, 12, i)
+
+		ok, err = s.Convert(&str, "12", nil)
+		assert.False(t, ok)
+		assert.Nil(t, err)
+	})
+
+	t.Run("should use the options of a binder", func(t *testing.T) {
+		b := New(WithFailFast(), WithConverter(reflect.TypeOf(0), func(s string, _ map[string]string) (any, error) {
+			return strconv.Atoi(s)
+		}))
+		s := b.Sources(req)
+
+		var i int
+
+		ok, err := s.Convert(&i, "7", nil)
+		assert.True(t, ok)
+		assert.Nil(t, err)
+		assert.Equal(t, 7, i)
+		assert.True(t, s.FailFast())
+		assert.False(t, NewSources(req).FailFast())
+	})
+}
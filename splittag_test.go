@@ -36,6 +36,17 @@ func TestSplitTag(t *testing.T) {
 			Label:        "should succeed with request-body kind",
 			ExpectedKind: requestBodyTag,
 		},
+		{
+			Label:        "should succeed with group kind",
+			ExpectedKind: groupTag,
+		},
+		{
+			Label:        "should succeed with group kind and prefix",
+			ExpectedKind: groupTag,
+			ExpectedMeta: map[string]string{
+				prefixMeta: "filter.",
+			},
+		},
 		{
 			Label:         "should fail with invalid KV tag",
 			ExpectedKind:  urlParamTag,
@@ -86,13 +97,12 @@ func TestSplitTag(t *testing.T) {
 		test := test
 		t.Run(test.Label, func(t *testing.T) {
 			tag := makeTag(test.ExpectedKind, test.ExpectedSource, test.ExpectedMeta)
-			kind, source, meta, err := splitTag(tag)
+			sources, meta, err := splitTag(tag)
 
 			if test.ExpectedError != nil {
 				assert.Equal(t, test.ExpectedError, err)
 			} else {
-				assert.Equal(t, test.ExpectedKind, kind)
-				assert.Equal(t, test.ExpectedSource, source)
+				assert.Equal(t, []TagSource{{Kind: test.ExpectedKind, Source: test.ExpectedSource}}, sources)
 				assert.Equal(t, test.ExpectedMeta, meta)
 				assert.Nil(t, err)
 			}
@@ -100,6 +110,57 @@ func TestSplitTag(t *testing.T) {
 	}
 }
 
+func TestSplitTagBareKeys(t *testing.T) {
+	t.Run("should accept required", func(t *testing.T) {
+		_, meta, err := splitTag("url-query=sort, required")
+
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]string{requiredMeta: ""}, meta)
+	})
+
+	t.Run("should reject other bare keys", func(t *testing.T) {
+		for _, tag := range []string{"url-query=x,requried", "url-query=since,RFC3339", "url-query=page,default"} {
+			_, _, err := splitTag(tag)
+
+			assert.Equal(t, ErrInvalidParamTagKeyValue, err, tag)
+		}
+	})
+
+	t.Run("should reject values for required", func(t *testing.T) {
+		for _, tag := range []string{"url-query=name,required=false", "url-query=name,required=true"} {
+			_, _, err := splitTag(tag)
+
+			assert.Equal(t, ErrInvalidParamTagKeyValue, err, tag)
+		}
+	})
+}
+
+func TestSplitTagChain(t *testing.T) {
+	t.Run("should split the sources in order", func(t *testing.T) {
+		sources, meta, err := splitTag("url-param=tenant| header=X-Tenant |url-query=tenant,oneof=a|b")
+
+		assert.Nil(t, err)
+		assert.Equal(t, []TagSource{
+			{Kind: urlParamTag, Source: "tenant"},
+			{Kind: headerTag, Source: "X-Tenant"},
+			{Kind: urlQueryTag, Source: "tenant"},
+		}, sources)
+		assert.Equal(t, map[string]string{"oneof": "a|b"}, meta)
+	})
+
+	t.Run("should fail with an empty source", func(t *testing.T) {
+		_, _, err := splitTag("url-param=tenant||header=X-Tenant")
+
+		assert.Equal(t, ErrInvalidParamTag, err)
+	})
+
+	t.Run("should fail with an invalid source", func(t *testing.T) {
+		_, _, err := splitTag("url-param=tenant|header,required")
+
+		assert.Equal(t, ErrInvalidParamTagKeyValue, err)
+	})
+}
+
 func makeTag(kind, source string, meta map[string]string) string {
 	m := ""
 	parts := make([]string, 0, len(meta))
@@ -0,0 +1,74 @@
+package httprequest
+
+import (
+	"net/http"
+)
+
+type (
+	// Validator is implemented by targets and request bodies that check
+	// themselves once bound, such as rules spanning several fields.
+	Validator interface {
+		Validate() error
+	}
+
+	// RequestValidator is Validator with access to the request. It is called
+	// instead of Validate when a value implements both.
+	RequestValidator interface {
+		ValidateRequest(*http.Request) error
+	}
+
+	// ValidateFunc validates a bound target, obj being the pointer given to
+	// As. It is set with WithValidator.
+	ValidateFunc func(req *http.Request, obj any) error
+)
+
+// validateBody runs the validation method of the bound body v, a pointer,
+// adding its errors to errs.
+func (b *binding) validateBody(errs BindingErrors, name string, v any) BindingErrors {
+	if b.cfg.FailFast && len(errs) > 0 {
+		return errs
+	}
+	return append(errs, validationErrors(b.validate(v), name, requestBodyTag)...)
+}
+
+// validateTarget runs the validation method of obj, then the validator set
+// with WithValidator, adding their errors to errs. Neither runs when a field
+// failed to bind.
+func (b *binding) validateTarget(errs BindingErrors, obj any) BindingErrors {
+	if len(errs) > 0 {
+		return errs
+	}
+
+	errs = validationErrors(b.validate(obj), "", "")
+	if b.cfg.Validate == nil || (b.cfg.FailFast && len(errs) > 0) {
+		return errs
+	}
+	return append(errs, validationErrors(b.cfg.Validate(b.req, obj), "", "")...)
+}
+
+func (b *binding) validate(v any) error {
+	switch val := v.(type) {
+	case RequestValidator:
+		return val.ValidateRequest(b.req)
+	case Validator:
+		return val.Validate()
+	default:
+		return nil
+	}
+}
+
+// validationErrors returns err as binding errors. Errors other than
+// BindingErrors and *FieldError are attributed to the field name, or to the
+// whole target when name is empty.
+func validationErrors(err error, name, kind string) BindingErrors {
+	switch e := err.(type) {
+	case nil:
+		return nil
+	case BindingErrors:
+		return append(BindingErrors(nil), e...)
+	case *FieldError:
+		return BindingErrors{e}
+	default:
+		return BindingErrors{{Field: name, Kind: kind, Err: err}}
+	}
+}